@@ -0,0 +1,109 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/go-generic/internal/list"
+)
+
+// LRU 并发安全的 LRU 缓存，容量固定
+// 使用 map 来查找元素，使用双向链表来记录最近访问的顺序，Get 和 Put 的时间复杂度都是 O(1)
+// 缓存满了之后，Put 新的 key 会淘汰最久没有被访问的元素
+type LRU[K comparable, V any] struct {
+	// 链表头部是最近访问的元素，尾部是最久没有被访问的元素
+	l        *list.LinkedList[entry[K, V]]
+	nodes    map[K]*list.Node[entry[K, V]]
+	capacity int
+	mutex    sync.Mutex
+}
+
+// NewLRU 创建一个容量为 capacity 的 LRU 缓存
+// capacity <= 0 的时候会 panic
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	if capacity <= 0 {
+		panic("cache: LRU 的容量必须大于 0")
+	}
+	return &LRU[K, V]{
+		l:        list.NewLinkedList[entry[K, V]](),
+		nodes:    make(map[K]*list.Node[entry[K, V]], capacity),
+		capacity: capacity,
+	}
+}
+
+// Get 返回 key 对应的值和 true，并且将 key 标记为最近访问
+// key 不存在的时候返回零值和 false
+func (l *LRU[K, V]) Get(key K) (V, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	n, ok := l.nodes[key]
+	if !ok {
+		var v V
+		return v, false
+	}
+	l.l.MoveToFront(n)
+	return n.Val.val, true
+}
+
+// Put 设置 key 对应的值，并且将 key 标记为最近访问
+// key 已经存在的时候覆盖原来的值；key 不存在并且缓存已满的时候，淘汰最久没有被访问的元素
+func (l *LRU[K, V]) Put(key K, val V) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if n, ok := l.nodes[key]; ok {
+		n.Val.val = val
+		l.l.MoveToFront(n)
+		return
+	}
+	if len(l.nodes) >= l.capacity {
+		l.remove(l.l.Back())
+	}
+	l.nodes[key] = l.l.PushFront(entry[K, V]{key: key, val: val})
+}
+
+// Remove 删除 key，返回 key 是否存在
+func (l *LRU[K, V]) Remove(key K) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	n, ok := l.nodes[key]
+	if ok {
+		l.remove(n)
+	}
+	return ok
+}
+
+// Len 返回缓存中的元素个数
+func (l *LRU[K, V]) Len() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return len(l.nodes)
+}
+
+// Cap 返回缓存的容量
+func (l *LRU[K, V]) Cap() int {
+	return l.capacity
+}
+
+// remove 从链表和 map 中删除节点 n，必须在锁范围内调用
+func (l *LRU[K, V]) remove(n *list.Node[entry[K, V]]) {
+	l.l.Remove(n)
+	delete(l.nodes, n.Val.key)
+}
+
+type entry[K comparable, V any] struct {
+	key K
+	val V
+}
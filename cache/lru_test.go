@@ -0,0 +1,185 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	type op struct {
+		put     bool
+		key     string
+		val     int
+		wantVal int
+		wantOk  bool
+	}
+	testCases := []struct {
+		name     string
+		capacity int
+		ops      []op
+		wantKeys []string
+	}{
+		{
+			name:     "get from empty",
+			capacity: 2,
+			ops: []op{
+				{key: "a"},
+			},
+			wantKeys: []string{},
+		},
+		{
+			name:     "put and get",
+			capacity: 2,
+			ops: []op{
+				{put: true, key: "a", val: 1},
+				{put: true, key: "b", val: 2},
+				{key: "a", wantVal: 1, wantOk: true},
+				{key: "b", wantVal: 2, wantOk: true},
+			},
+			wantKeys: []string{"b", "a"},
+		},
+		{
+			name:     "evict least recently put",
+			capacity: 2,
+			ops: []op{
+				{put: true, key: "a", val: 1},
+				{put: true, key: "b", val: 2},
+				{put: true, key: "c", val: 3},
+				{key: "a"},
+				{key: "c", wantVal: 3, wantOk: true},
+			},
+			wantKeys: []string{"c", "b"},
+		},
+		{
+			name:     "get refreshes recency",
+			capacity: 2,
+			ops: []op{
+				{put: true, key: "a", val: 1},
+				{put: true, key: "b", val: 2},
+				{key: "a", wantVal: 1, wantOk: true},
+				{put: true, key: "c", val: 3},
+				{key: "b"},
+			},
+			wantKeys: []string{"c", "a"},
+		},
+		{
+			name:     "put existing key refreshes recency",
+			capacity: 2,
+			ops: []op{
+				{put: true, key: "a", val: 1},
+				{put: true, key: "b", val: 2},
+				{put: true, key: "a", val: 10},
+				{put: true, key: "c", val: 3},
+				{key: "a", wantVal: 10, wantOk: true},
+				{key: "b"},
+			},
+			wantKeys: []string{"a", "c"},
+		},
+		{
+			name:     "capacity 1",
+			capacity: 1,
+			ops: []op{
+				{put: true, key: "a", val: 1},
+				{put: true, key: "b", val: 2},
+				{key: "a"},
+				{key: "b", wantVal: 2, wantOk: true},
+			},
+			wantKeys: []string{"b"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewLRU[string, int](tc.capacity)
+			for _, o := range tc.ops {
+				if o.put {
+					l.Put(o.key, o.val)
+					continue
+				}
+				val, ok := l.Get(o.key)
+				assert.Equal(t, o.wantOk, ok)
+				assert.Equal(t, o.wantVal, val)
+			}
+			assert.Equal(t, len(tc.wantKeys), l.Len())
+			assert.Equal(t, tc.wantKeys, l.keys())
+		})
+	}
+
+	assert.Panics(t, func() {
+		NewLRU[string, int](0)
+	})
+}
+
+func TestLRU_Remove(t *testing.T) {
+	l := NewLRU[string, int](2)
+	l.Put("a", 1)
+	l.Put("b", 2)
+	assert.True(t, l.Remove("a"))
+	assert.False(t, l.Remove("a"))
+	assert.Equal(t, []string{"b"}, l.keys())
+	// 删除之后腾出了位置，不会淘汰 b
+	l.Put("c", 3)
+	assert.Equal(t, []string{"c", "b"}, l.keys())
+	assert.Equal(t, 2, l.Cap())
+}
+
+func TestLRU_Concurrent(t *testing.T) {
+	l := NewLRU[int, int](10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Put(i*100+j, j)
+				l.Get(i*100 + j - 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 10, l.Len())
+	assert.Len(t, l.keys(), 10)
+}
+
+// keys 按照最近访问的顺序返回所有的 key，不会修改访问顺序
+func (l *LRU[K, V]) keys() []K {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	res := make([]K, 0, len(l.nodes))
+	for n := l.l.Front(); n != nil; n = l.l.Next(n) {
+		res = append(res, n.Val.key)
+	}
+	return res
+}
+
+func ExampleNewLRU() {
+	l := NewLRU[string, int](2)
+	l.Put("a", 1)
+	l.Put("b", 2)
+	// 访问 a 之后，b 变成了最久没有被访问的元素
+	l.Get("a")
+	l.Put("c", 3)
+	_, ok := l.Get("b")
+	fmt.Println(ok)
+	val, ok := l.Get("a")
+	fmt.Println(val, ok)
+	// Output:
+	// false
+	// 1 true
+}
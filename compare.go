@@ -31,3 +31,49 @@ func ComparatorRealNumber[T RealNumber](src T, dst T) int {
 		return 1
 	}
 }
+
+// Reverse 返回一个和 compare 结果相反的比较函数
+// 例如将 Reverse(compare) 传给 NewPriorityQueue，就可以得到一个大顶堆
+func Reverse[T any](compare Comparator[T]) Comparator[T] {
+	return func(src T, dst T) int {
+		// 交换参数而不是对结果取反，相等的情况下依旧返回 0
+		return compare(dst, src)
+	}
+}
+
+// ComparatorFromOrdered 返回一个按照 < 比较大小的比较函数，支持所有 Ordered 类型，包括 string
+// 对于实数也可以直接使用 ComparatorRealNumber
+func ComparatorFromOrdered[T Ordered]() Comparator[T] {
+	return func(src T, dst T) int {
+		if src < dst {
+			return -1
+		}
+		if src == dst {
+			return 0
+		}
+		return 1
+	}
+}
+
+// ComparatorByKey 返回一个按照 key 比较大小的比较函数
+// 例如按照结构体的某个字段排序：ComparatorByKey(func(u User) int { return u.Age })
+func ComparatorByKey[T any, K Ordered](key func(T) K) Comparator[T] {
+	cmp := ComparatorFromOrdered[K]()
+	return func(src T, dst T) int {
+		return cmp(key(src), key(dst))
+	}
+}
+
+// CombineComparators 将多个比较函数组合成一个，依次使用每个比较函数比较，返回第一个非 0 的结果
+// 所有比较函数都返回 0 的时候，返回 0；没有传入比较函数的时候，认为所有元素都相等
+// 例如先按照年龄排序，年龄相同的再按照名字排序
+func CombineComparators[T any](cmps ...Comparator[T]) Comparator[T] {
+	return func(src T, dst T) int {
+		for _, cmp := range cmps {
+			if res := cmp(src, dst); res != 0 {
+				return res
+			}
+		}
+		return 0
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverse(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  int
+		dst  int
+		want int
+	}{
+		{
+			name: "src < dst",
+			src:  1,
+			dst:  2,
+			want: 1,
+		},
+		{
+			name: "src = dst",
+			src:  2,
+			dst:  2,
+			want: 0,
+		},
+		{
+			name: "src > dst",
+			src:  3,
+			dst:  2,
+			want: -1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmp := Reverse[int](ComparatorRealNumber[int])
+			assert.Equal(t, tc.want, cmp(tc.src, tc.dst))
+		})
+	}
+}
+
+func TestComparatorFromOrdered(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		dst  string
+		want int
+	}{
+		{
+			name: "src < dst",
+			src:  "a",
+			dst:  "b",
+			want: -1,
+		},
+		{
+			name: "src = dst",
+			src:  "b",
+			dst:  "b",
+			want: 0,
+		},
+		{
+			name: "src > dst",
+			src:  "ba",
+			dst:  "b",
+			want: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmp := ComparatorFromOrdered[string]()
+			assert.Equal(t, tc.want, cmp(tc.src, tc.dst))
+		})
+	}
+}
+
+func TestComparatorByKey(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  compareUser
+		dst  compareUser
+		want int
+	}{
+		{
+			name: "src < dst",
+			src:  compareUser{name: "b", age: 10},
+			dst:  compareUser{name: "a", age: 20},
+			want: -1,
+		},
+		{
+			name: "src = dst",
+			src:  compareUser{name: "b", age: 20},
+			dst:  compareUser{name: "a", age: 20},
+			want: 0,
+		},
+		{
+			name: "src > dst",
+			src:  compareUser{name: "a", age: 30},
+			dst:  compareUser{name: "b", age: 20},
+			want: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmp := ComparatorByKey(func(u compareUser) int {
+				return u.age
+			})
+			assert.Equal(t, tc.want, cmp(tc.src, tc.dst))
+		})
+	}
+}
+
+func TestCombineComparators(t *testing.T) {
+	byAge := ComparatorByKey(func(u compareUser) int {
+		return u.age
+	})
+	byName := ComparatorByKey(func(u compareUser) string {
+		return u.name
+	})
+	testCases := []struct {
+		name  string
+		cmps  []Comparator[compareUser]
+		users []compareUser
+		want  []compareUser
+	}{
+		{
+			name: "no comparator",
+			users: []compareUser{
+				{name: "b", age: 20}, {name: "a", age: 10},
+			},
+			want: []compareUser{
+				{name: "b", age: 20}, {name: "a", age: 10},
+			},
+		},
+		{
+			name: "age then name",
+			cmps: []Comparator[compareUser]{byAge, byName},
+			users: []compareUser{
+				{name: "c", age: 20}, {name: "b", age: 10}, {name: "a", age: 20},
+				{name: "d", age: 10}, {name: "a", age: 30},
+			},
+			want: []compareUser{
+				{name: "b", age: 10}, {name: "d", age: 10}, {name: "a", age: 20},
+				{name: "c", age: 20}, {name: "a", age: 30},
+			},
+		},
+		{
+			name: "name then reversed age",
+			cmps: []Comparator[compareUser]{byName, Reverse(byAge)},
+			users: []compareUser{
+				{name: "c", age: 20}, {name: "b", age: 10}, {name: "a", age: 20},
+				{name: "d", age: 10}, {name: "a", age: 30},
+			},
+			want: []compareUser{
+				{name: "a", age: 30}, {name: "a", age: 20}, {name: "b", age: 10},
+				{name: "c", age: 20}, {name: "d", age: 10},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmp := CombineComparators(tc.cmps...)
+			sort.SliceStable(tc.users, func(i, j int) bool {
+				return cmp(tc.users[i], tc.users[j]) < 0
+			})
+			assert.Equal(t, tc.want, tc.users)
+		})
+	}
+}
+
+type compareUser struct {
+	name string
+	age  int
+}
+
+func ExampleComparatorByKey() {
+	users := []compareUser{{name: "Tom", age: 30}, {name: "Jerry", age: 20}}
+	cmp := ComparatorByKey(func(u compareUser) int {
+		return u.age
+	})
+	sort.Slice(users, func(i, j int) bool {
+		return cmp(users[i], users[j]) < 0
+	})
+	fmt.Println(users[0].name, users[1].name)
+	// Output:
+	// Jerry Tom
+}
@@ -26,6 +26,12 @@ type RealNumber interface {
 		~float32 | ~float64
 }
 
+// Number 数字，包括实数和复数
 type Number interface {
 	RealNumber | ~complex64 | ~complex128
 }
+
+// Ordered 可以使用 < <= > >= 比较大小的类型
+type Ordered interface {
+	RealNumber | ~string
+}
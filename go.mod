@@ -5,6 +5,7 @@ go 1.22.4
 require (
 	github.com/stretchr/testify v1.10.0
 	golang.org/x/exp v0.0.0-20241108190413-2d47ceb2692f
+	golang.org/x/sync v0.9.0
 )
 
 require (
@@ -15,13 +15,34 @@
 package errs
 
 import (
+	"errors"
 	"fmt"
 	"time"
 )
 
+// ErrIndexOutOfRange 下标超出范围，所有的 IndexOutOfRangeError 都可以通过 errors.Is 匹配到它
+// 适合不关心具体下标和长度的调用者
+var ErrIndexOutOfRange = errors.New("ekit: 下标超出范围")
+
+// IndexOutOfRangeError 下标超出范围的错误，带上了访问的下标和当时的长度
+// 可以通过 errors.As 拿到具体的下标和长度
+type IndexOutOfRangeError struct {
+	Length int
+	Index  int
+}
+
+func (e *IndexOutOfRangeError) Error() string {
+	return fmt.Sprintf("ekit: 下标超出范围，长度 %d, 下标 %d", e.Length, e.Index)
+}
+
+// Is 让 errors.Is(err, ErrIndexOutOfRange) 返回 true
+func (e *IndexOutOfRangeError) Is(target error) bool {
+	return target == ErrIndexOutOfRange
+}
+
 // NewErrIndexOutOfRange 创建一个代表下标超出范围的错误
 func NewErrIndexOutOfRange(length int, index int) error {
-	return fmt.Errorf("ekit: 下标超出范围，长度 %d, 下标 %d", length, index)
+	return &IndexOutOfRangeError{Length: length, Index: index}
 }
 
 // NewErrInvalidType 创建一个代表类型转换失败的错误
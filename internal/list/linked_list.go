@@ -0,0 +1,112 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+// LinkedList 基于哨兵节点的双向循环链表，不是并发安全的
+// 插入、删除和移动节点的时间复杂度都是 O(1)
+// 由 queue.Deque 和 cache.LRU 共用，使用方自己负责加锁
+type LinkedList[T any] struct {
+	// 哨兵节点，head.next 是第一个节点，head.prev 是最后一个节点
+	head   *Node[T]
+	length int
+}
+
+// Node 链表节点，插入元素的时候返回，之后可以用来 O(1) 地删除或者移动这个节点
+type Node[T any] struct {
+	Val  T
+	prev *Node[T]
+	next *Node[T]
+}
+
+// NewLinkedList 创建一个空链表
+func NewLinkedList[T any]() *LinkedList[T] {
+	head := &Node[T]{}
+	head.prev, head.next = head, head
+	return &LinkedList[T]{
+		head: head,
+	}
+}
+
+// PushFront 在链表头部插入元素，返回新的节点
+func (l *LinkedList[T]) PushFront(t T) *Node[T] {
+	return l.insertAfter(l.head, &Node[T]{Val: t})
+}
+
+// PushBack 在链表尾部插入元素，返回新的节点
+func (l *LinkedList[T]) PushBack(t T) *Node[T] {
+	return l.insertAfter(l.head.prev, &Node[T]{Val: t})
+}
+
+// Front 返回第一个节点，链表为空的时候返回 nil
+func (l *LinkedList[T]) Front() *Node[T] {
+	if l.length == 0 {
+		return nil
+	}
+	return l.head.next
+}
+
+// Back 返回最后一个节点，链表为空的时候返回 nil
+func (l *LinkedList[T]) Back() *Node[T] {
+	if l.length == 0 {
+		return nil
+	}
+	return l.head.prev
+}
+
+// Next 返回 n 的下一个节点，n 是最后一个节点的时候返回 nil
+func (l *LinkedList[T]) Next(n *Node[T]) *Node[T] {
+	if n.next == l.head {
+		return nil
+	}
+	return n.next
+}
+
+// Remove 删除节点 n，返回节点中的元素
+// n 必须是这个链表中的节点
+func (l *LinkedList[T]) Remove(n *Node[T]) T {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	// 断开引用，方便 GC
+	n.prev, n.next = nil, nil
+	l.length--
+	return n.Val
+}
+
+// MoveToFront 将节点 n 移动到链表头部
+// n 必须是这个链表中的节点
+func (l *LinkedList[T]) MoveToFront(n *Node[T]) {
+	if l.head.next == n {
+		return
+	}
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	l.length--
+	l.insertAfter(l.head, n)
+}
+
+// Len 返回链表中的元素个数
+func (l *LinkedList[T]) Len() int {
+	return l.length
+}
+
+// insertAfter 在 at 之后插入节点 n
+func (l *LinkedList[T]) insertAfter(at *Node[T], n *Node[T]) *Node[T] {
+	n.prev = at
+	n.next = at.next
+	at.next.prev = n
+	at.next = n
+	l.length++
+	return n
+}
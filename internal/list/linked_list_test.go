@@ -0,0 +1,117 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkedList_Push(t *testing.T) {
+	l := NewLinkedList[int]()
+	assert.Nil(t, l.Front())
+	assert.Nil(t, l.Back())
+	l.PushBack(2)
+	l.PushFront(1)
+	l.PushBack(3)
+	assert.Equal(t, 3, l.Len())
+	assert.Equal(t, []int{1, 2, 3}, values(l))
+	assert.Equal(t, 1, l.Front().Val)
+	assert.Equal(t, 3, l.Back().Val)
+}
+
+func TestLinkedList_Remove(t *testing.T) {
+	testCases := []struct {
+		name    string
+		remove  int
+		wantVal int
+		want    []int
+	}{
+		{
+			name:    "first",
+			remove:  0,
+			wantVal: 1,
+			want:    []int{2, 3},
+		},
+		{
+			name:    "middle",
+			remove:  1,
+			wantVal: 2,
+			want:    []int{1, 3},
+		},
+		{
+			name:    "last",
+			remove:  2,
+			wantVal: 3,
+			want:    []int{1, 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewLinkedList[int]()
+			nodes := []*Node[int]{l.PushBack(1), l.PushBack(2), l.PushBack(3)}
+			assert.Equal(t, tc.wantVal, l.Remove(nodes[tc.remove]))
+			assert.Equal(t, len(tc.want), l.Len())
+			assert.Equal(t, tc.want, values(l))
+		})
+	}
+
+	l := NewLinkedList[int]()
+	l.Remove(l.PushBack(1))
+	assert.Equal(t, 0, l.Len())
+	assert.Nil(t, l.Front())
+}
+
+func TestLinkedList_MoveToFront(t *testing.T) {
+	testCases := []struct {
+		name string
+		move int
+		want []int
+	}{
+		{
+			name: "first",
+			move: 0,
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "middle",
+			move: 1,
+			want: []int{2, 1, 3},
+		},
+		{
+			name: "last",
+			move: 2,
+			want: []int{3, 1, 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewLinkedList[int]()
+			nodes := []*Node[int]{l.PushBack(1), l.PushBack(2), l.PushBack(3)}
+			l.MoveToFront(nodes[tc.move])
+			assert.Equal(t, 3, l.Len())
+			assert.Equal(t, tc.want, values(l))
+		})
+	}
+}
+
+func values[T any](l *LinkedList[T]) []T {
+	res := make([]T, 0, l.Len())
+	for n := l.Front(); n != nil; n = l.Next(n) {
+		res = append(res, n.Val)
+	}
+	return res
+}
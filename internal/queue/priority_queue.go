@@ -16,6 +16,7 @@ package queue
 
 import (
 	"errors"
+	"fmt"
 	"github.com/go-generic"
 
 	"github.com/go-generic/internal/slice"
@@ -36,6 +37,30 @@ type PriorityQueue[T any] struct {
 	capacity int
 	// 队列中的元素，为便于计算父子节点的index，0位置留空，根节点从1开始
 	data []T
+	// 无界队列的缩容策略
+	shrinkPolicy slice.ShrinkPolicy
+}
+
+// Option 优先队列的可选配置
+type Option[T any] func(p *PriorityQueue[T])
+
+// WithInitialCap 预先为无界队列分配能够容纳 initialCap 个元素的底层切片，默认的切片容量是 64
+// 适合预先知道元素个数的场景，可以避免入队的时候频繁扩容
+// 注意：出队之后依旧会按照缩容策略缩容。对有界队列，或者 initialCap <= 0 的情况没有影响
+func WithInitialCap[T any](initialCap int) Option[T] {
+	return func(p *PriorityQueue[T]) {
+		if p.IsBoundless() && initialCap > 0 {
+			p.data = make([]T, 1, initialCap+1)
+		}
+	}
+}
+
+// WithShrinkPolicy 设置无界队列出队之后的缩容策略，默认是 slice.DefaultShrinkPolicy
+// 传入 slice.NoShrink 可以禁止缩容。对有界队列没有影响
+func WithShrinkPolicy[T any](policy slice.ShrinkPolicy) Option[T] {
+	return func(p *PriorityQueue[T]) {
+		p.shrinkPolicy = policy
+	}
 }
 
 // Len 优先队列长度
@@ -63,7 +88,7 @@ func (p *PriorityQueue[T]) isEmpty() bool {
 	return len(p.data) < 2
 }
 
-// 回优先队列中的最小元素,而不将其从队列中移除
+// Peek 返回优先队列中的最小元素,而不将其从队列中移除
 func (p *PriorityQueue[T]) Peek() (T, error) {
 	if p.isEmpty() {
 		var t T
@@ -72,6 +97,58 @@ func (p *PriorityQueue[T]) Peek() (T, error) {
 	return p.data[1], nil
 }
 
+// PeekN 按照出队的顺序返回最小的 n 个元素，而不将它们从队列中移除
+// n 大于队列长度的时候返回所有元素，队列为空的时候返回空切片
+func (p *PriorityQueue[T]) PeekN(n int) []T {
+	n = max(min(n, p.Len()), 0)
+	res := make([]T, 0, n)
+	// 在副本上出队，不影响原来的堆
+	cp := p.Clone()
+	for i := 0; i < n; i++ {
+		val, _ := cp.Dequeue()
+		res = append(res, val)
+	}
+	return res
+}
+
+// AsSortedSlice 按照出队的顺序返回队列中的所有元素，而不将它们从队列中移除
+// 本质上是在副本上执行堆排序，时间复杂度 O(nlogn)
+// 每次调用都会返回一个新的切片
+func (p *PriorityQueue[T]) AsSortedSlice() []T {
+	return p.PeekN(p.Len())
+}
+
+// Iterate 遍历队列中的所有元素，但是不会将它们出队
+// 注意：遍历是按照堆的存储顺序进行的，而不是按照出队的顺序，idx 是元素在堆中的位置，从 0 开始
+// fn 返回 false 的时候会中断遍历。在遍历的过程中不能修改队列
+func (p *PriorityQueue[T]) Iterate(fn func(idx int, val T) bool) {
+	for i := 1; i < len(p.data); i++ {
+		if !fn(i-1, p.data[i]) {
+			return
+		}
+	}
+}
+
+// String 按照堆的存储顺序输出队列中的所有元素，格式和 fmt.Sprint 输出切片一致，例如 [1 3 2]
+// 注意：堆的存储顺序不是出队的顺序，只有第一个元素一定是下一个出队的元素
+func (p *PriorityQueue[T]) String() string {
+	return fmt.Sprint(p.data[1:])
+}
+
+// Clone 复制一个优先队列，两者使用相同的比较函数和容量
+// 副本拥有独立的底层切片，修改其中一个不会影响另外一个
+// 注意：元素本身是浅拷贝的
+func (p *PriorityQueue[T]) Clone() *PriorityQueue[T] {
+	data := make([]T, len(p.data), cap(p.data))
+	copy(data, p.data)
+	return &PriorityQueue[T]{
+		compare:      p.compare,
+		capacity:     p.capacity,
+		data:         data,
+		shrinkPolicy: p.shrinkPolicy,
+	}
+}
+
 // Enqueue 新元素入队
 func (p *PriorityQueue[T]) Enqueue(t T) error {
 	// 判断是否满
@@ -81,20 +158,78 @@ func (p *PriorityQueue[T]) Enqueue(t T) error {
 
 	p.data = append(p.data, t)
 	//进行上浮操作,将新元素上移到合适的位置,以满足小顶堆的性质
-	node, parent := len(p.data)-1, (len(p.data)-1)/2
-	//从新元素的位置开始,与其父节点进行比较。
-	//如果新元素小于父节点,则交换它们的位置。
-	//重复这个过程,直到新元素的位置满足小顶堆的性质或到达根节点。
-	for parent > 0 && p.compare(p.data[node], p.data[parent]) < 0 {
-		p.data[parent], p.data[node] = p.data[node], p.data[parent]
-		node = parent
-		parent = parent / 2
+	p.shiftUp(len(p.data) - 1)
+	return nil
+}
+
+// EnqueueOrReplace 入队，有界队列已满的时候不会返回 ErrOutOfCapacity，而是淘汰一个元素，适合实现 top-K
+// 队列已满的时候，t 会和堆顶（也就是下一个出队的元素）比较：
+//   - 如果 t 比堆顶大，堆顶被淘汰，t 入队
+//   - 否则 t 本身被淘汰，队列保持不变
+//
+// 也就是说队列始终保留最大的 Cap() 个元素，例如使用 generic.Reverse 构造的大顶堆，保留的就是最小的 Cap() 个元素
+// 发生淘汰的时候返回被淘汰的元素和 true（被淘汰的可能就是 t），否则返回零值和 false
+// 无界队列不会淘汰元素，和 Enqueue 一样
+func (p *PriorityQueue[T]) EnqueueOrReplace(t T) (evicted T, didEvict bool, err error) {
+	if !p.isFull() {
+		return evicted, false, p.Enqueue(t)
+	}
+	if p.compare(t, p.data[1]) <= 0 {
+		return t, true, nil
 	}
+	evicted = p.data[1]
+	p.data[1] = t
+	p.shiftDown(1)
+	return evicted, true, nil
+}
 
+// EnqueueAll 批量入队
+// 先把所有元素追加到队尾，再自底向上重新建堆，时间复杂度 O(n)，比逐个入队的 O(nlogn) 更快
+// 对于有界队列，如果入队之后会超出容量，返回 ErrOutOfCapacity，并且队列保持不变
+func (p *PriorityQueue[T]) EnqueueAll(items []T) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if !p.IsBoundless() && p.Len()+len(items) > p.capacity {
+		return ErrOutOfCapacity
+	}
+	p.data = append(p.data, items...)
+	p.buildHeap()
 	return nil
 }
 
-// 优先队列出库
+// Merge 将 other 中的所有元素合并到当前队列中，other 本身保持不变
+// 和 EnqueueAll 一样，先追加元素再重新建堆，时间复杂度 O(n)
+// 对于有界队列，如果合并之后会超出容量，返回 ErrOutOfCapacity，并且队列保持不变
+// other 为 nil 的时候当作空队列处理
+// 注意：两个队列必须使用相同的比较函数，否则合并之后的出队顺序是未定义的
+func (p *PriorityQueue[T]) Merge(other *PriorityQueue[T]) error {
+	if other == nil {
+		return nil
+	}
+	return p.EnqueueAll(other.data[1:])
+}
+
+// buildHeap 自底向上建堆，从最后一个非叶子节点开始逐个下沉
+func (p *PriorityQueue[T]) buildHeap() {
+	n := len(p.data) - 1
+	for i := n / 2; i > 0; i-- {
+		p.shiftDown(i)
+	}
+}
+
+// shiftUp 上浮操作
+// 从 node 的位置开始,与其父节点进行比较。
+// 如果 node 小于父节点,则交换它们的位置。
+// 重复这个过程,直到 node 的位置满足小顶堆的性质或到达根节点。
+func (p *PriorityQueue[T]) shiftUp(node int) {
+	for parent := node / 2; parent > 0 && p.compare(p.data[node], p.data[parent]) < 0; parent = node / 2 {
+		p.data[parent], p.data[node] = p.data[node], p.data[parent]
+		node = parent
+	}
+}
+
+// Dequeue 出队，返回优先队列中的最小元素
 func (p *PriorityQueue[T]) Dequeue() (T, error) {
 	if p.isEmpty() {
 		var t T
@@ -108,17 +243,131 @@ func (p *PriorityQueue[T]) Dequeue() (T, error) {
 	// 如果是无界队列，则对data切片缩容
 	p.shrinkIfNecessary()
 	// 从data[1]开始往后，构造成一个堆序列
-	p.heapify(p.data, len(p.data)-1, 1)
+	p.shiftDown(1)
 	return pop, nil
 }
 
+// DequeueLessThan 按照出队的顺序，将所有比 threshold 小的元素出队
+// 每次比较堆顶和 threshold，只要 compare(堆顶, threshold) < 0 就出队，所以和 threshold 相等的元素会留在队列中
+// 类似于 DelayQueue 批量出队已经到期的元素，只是适用于任意的比较函数
+// 没有满足条件的元素的时候返回一个空切片而不是 nil
+func (p *PriorityQueue[T]) DequeueLessThan(threshold T) []T {
+	res := make([]T, 0)
+	for !p.isEmpty() && p.compare(p.data[1], threshold) < 0 {
+		val, _ := p.Dequeue()
+		res = append(res, val)
+	}
+	return res
+}
+
+// Remove 删除第一个满足 match 的元素，返回被删除的元素和 true
+// 如果没有满足条件的元素，返回零值和 false
+// 注意：查找是按照堆的存储顺序进行的，而不是按照出队的顺序
+func (p *PriorityQueue[T]) Remove(match func(T) bool) (T, bool) {
+	i := p.indexFunc(match)
+	if i < 0 {
+		var t T
+		return t, false
+	}
+	return p.removeAt(i), true
+}
+
+// Contains 判断队列中是否存在满足 match 的元素
+// 堆没有按照元素建立索引，所以时间复杂度是 O(n)
+func (p *PriorityQueue[T]) Contains(match func(T) bool) bool {
+	return p.indexFunc(match) > 0
+}
+
+// indexFunc 按照堆的存储顺序查找第一个满足 match 的元素，返回它在 data 中的下标
+// 没有找到的时候返回 -1
+func (p *PriorityQueue[T]) indexFunc(match func(T) bool) int {
+	for i := 1; i < len(p.data); i++ {
+		if match(p.data[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeAt 删除下标为 i 的元素
+// 将最后一个元素移动到 i 的位置，然后根据它和子节点、父节点的大小关系，进行下沉或者上浮
+// 无界队列会按照缩容策略缩容，和 Dequeue 保持一致
+func (p *PriorityQueue[T]) removeAt(i int) T {
+	res := p.data[i]
+	last := len(p.data) - 1
+	p.data[i] = p.data[last]
+	p.data = p.data[:last]
+	// 和 Dequeue 一样，如果是无界队列，则对data切片缩容
+	p.shrinkIfNecessary()
+	if i < last {
+		p.fix(i)
+	}
+	return res
+}
+
+// Update 将第一个满足 match 的元素替换为 newVal，并且调整它在堆中的位置，返回 true
+// 如果没有满足条件的元素，队列保持不变，返回 false
+// newVal 比原来的元素小的时候会上浮，比原来的元素大的时候会下沉，可以用于实现 decrease-key 之类的操作
+// 注意：查找是按照堆的存储顺序进行的，时间复杂度是 O(n)
+func (p *PriorityQueue[T]) Update(match func(T) bool, newVal T) bool {
+	i := p.indexFunc(match)
+	if i < 0 {
+		return false
+	}
+	p.data[i] = newVal
+	p.fix(i)
+	return true
+}
+
+// fix 在下标为 i 的元素发生变化之后，重新调整它的位置，恢复堆的性质
+// 最多只会有一个方向生效：下沉之后 i 位置的元素必然不小于父节点，上浮也就不会发生
+func (p *PriorityQueue[T]) fix(i int) {
+	p.shiftDown(i)
+	p.shiftUp(i)
+}
+
+// Resize 调整队列的容量
+// newCap <= 0 时，队列变为无界队列，底层切片会在后续出队时按需缩容
+// newCap > 0 时，队列变为有界队列，底层切片的容量会调整为 newCap+1，和 NewPriorityQueue 保持一致
+// 如果 newCap 小于当前的元素个数，返回 ErrOutOfCapacity，并且队列保持不变
+func (p *PriorityQueue[T]) Resize(newCap int) error {
+	if newCap <= 0 {
+		p.capacity = 0
+		return nil
+	}
+	if newCap < p.Len() {
+		return ErrOutOfCapacity
+	}
+	p.capacity = newCap
+	if cap(p.data) != newCap+1 {
+		data := make([]T, len(p.data), newCap+1)
+		copy(data, p.data)
+		p.data = data
+	}
+	return nil
+}
+
+// Clear 清空队列，但是保留底层切片的容量，方便复用
+// 有界队列的容量保持不变
+func (p *PriorityQueue[T]) Clear() {
+	// 清空引用，方便 GC
+	clear(p.data[1:])
+	p.data = p.data[:1]
+}
+
 // 对无界队列进行缩容
 func (p *PriorityQueue[T]) shrinkIfNecessary() {
 	if p.IsBoundless() {
-		p.data = slice.Shrink[T](p.data)
+		p.data = slice.ShrinkWithPolicy[T](p.data, p.shrinkPolicy)
 	}
 }
 
+// shiftDown 下沉操作
+// 从 node 的位置开始，与其较小的子节点进行比较，如果 node 大于子节点，则交换它们的位置，直到满足小顶堆的性质
+func (p *PriorityQueue[T]) shiftDown(node int) {
+	p.heapify(p.data, len(p.data)-1, node)
+}
+
 // 将一个无序的数组或线性数据结构转换为一个满足堆性质的数据结构
 func (p *PriorityQueue[T]) heapify(data []T, n, i int) {
 	minPos := i
@@ -139,15 +388,34 @@ func (p *PriorityQueue[T]) heapify(data []T, n, i int) {
 }
 
 // NewPriorityQueue 创建优先队列 capacity <= 0 时，为无界队列，否则有有界队列
-func NewPriorityQueue[T any](capacity int, compare generic.Comparator[T]) *PriorityQueue[T] {
+func NewPriorityQueue[T any](capacity int, compare generic.Comparator[T], opts ...Option[T]) *PriorityQueue[T] {
 	sliceCap := capacity + 1 // 切片长度 = 容量+1
 	if capacity < 1 {
 		capacity = 0
 		sliceCap = 64
 	}
-	return &PriorityQueue[T]{
-		capacity: capacity,
-		data:     make([]T, 1, sliceCap),
-		compare:  compare,
+	res := &PriorityQueue[T]{
+		capacity:     capacity,
+		data:         make([]T, 1, sliceCap),
+		compare:      compare,
+		shrinkPolicy: slice.DefaultShrinkPolicy,
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	return res
+}
+
+// NewPriorityQueueFromSlice 使用 data 中的元素创建优先队列，自底向上建堆，时间复杂度 O(n)
+// data 会被复制，之后修改 data 不会影响队列，反之亦然
+// capacity 的含义和 NewPriorityQueue 一致；如果是有界队列并且 data 的长度超出了 capacity，会 panic
+func NewPriorityQueueFromSlice[T any](capacity int, data []T, compare generic.Comparator[T], opts ...Option[T]) *PriorityQueue[T] {
+	if capacity > 0 && len(data) > capacity {
+		panic("queue: 元素个数超出了优先队列的容量")
 	}
+	res := NewPriorityQueue[T](capacity, compare, opts...)
+	// 0 位置是哨兵，元素从 1 开始存放
+	res.data = append(res.data, data...)
+	res.buildHeap()
+	return res
 }
@@ -15,7 +15,9 @@
 package queue
 
 import (
+	"fmt"
 	"github.com/go-generic"
+	"github.com/go-generic/internal/slice"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -75,6 +77,68 @@ func TestNewPriorityQueue(t *testing.T) {
 
 }
 
+func TestNewPriorityQueueFromSlice(t *testing.T) {
+	testCases := []struct {
+		name      string
+		capacity  int
+		data      []int
+		wantPanic bool
+		wantCap   int
+		wantOrder []int
+	}{
+		{
+			name:      "nil",
+			wantOrder: []int{},
+		},
+		{
+			name:      "无界队列",
+			data:      []int{6, 5, 4, 3, 2, 1},
+			wantOrder: []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:      "无界队列，超出初始容量",
+			data:      make([]int, 100),
+			wantOrder: make([]int, 100),
+		},
+		{
+			name:      "有界队列",
+			capacity:  8,
+			data:      []int{3, 1, 2},
+			wantCap:   8,
+			wantOrder: []int{1, 2, 3},
+		},
+		{
+			name:      "有界队列，超出容量",
+			capacity:  2,
+			data:      []int{3, 1, 2},
+			wantPanic: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.wantPanic {
+				assert.Panics(t, func() {
+					NewPriorityQueueFromSlice[int](tc.capacity, tc.data, compare())
+				})
+				return
+			}
+			src := append([]int(nil), tc.data...)
+			q := NewPriorityQueueFromSlice[int](tc.capacity, src, compare())
+			assert.Equal(t, tc.wantCap, q.Cap())
+			assert.Equal(t, len(tc.data), q.Len())
+			// 原始切片会被复制，不受建堆的影响
+			assert.Equal(t, tc.data, src)
+			res := make([]int, 0, q.Len())
+			for q.Len() > 0 {
+				el, err := q.Dequeue()
+				require.NoError(t, err)
+				res = append(res, el)
+			}
+			assert.Equal(t, tc.wantOrder, res)
+		})
+	}
+}
+
 func TestPriorityQueue_Peek(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -263,6 +327,231 @@ func TestPriorityQueue_EnqueueHeapStruct(t *testing.T) {
 	}
 }
 
+func TestPriorityQueue_EnqueueOrReplace(t *testing.T) {
+	testCases := []struct {
+		name         string
+		capacity     int
+		data         []int
+		element      int
+		wantEvicted  int
+		wantDidEvict bool
+		wantOrder    []int
+	}{
+		{
+			name:      "无界队列",
+			data:      []int{3, 1, 2},
+			element:   0,
+			wantOrder: []int{0, 1, 2, 3},
+		},
+		{
+			name:      "有界队列，没有满",
+			capacity:  4,
+			data:      []int{3, 1, 2},
+			element:   0,
+			wantOrder: []int{0, 1, 2, 3},
+		},
+		{
+			name:         "有界队列，已满，淘汰堆顶",
+			capacity:     3,
+			data:         []int{3, 1, 2},
+			element:      5,
+			wantEvicted:  1,
+			wantDidEvict: true,
+			wantOrder:    []int{2, 3, 5},
+		},
+		{
+			name:         "有界队列，已满，淘汰新元素",
+			capacity:     3,
+			data:         []int{3, 1, 2},
+			element:      0,
+			wantEvicted:  0,
+			wantDidEvict: true,
+			wantOrder:    []int{1, 2, 3},
+		},
+		{
+			name:         "有界队列，已满，和堆顶相等",
+			capacity:     3,
+			data:         []int{3, 1, 2},
+			element:      1,
+			wantEvicted:  1,
+			wantDidEvict: true,
+			wantOrder:    []int{1, 2, 3},
+		},
+		{
+			name:         "有界队列，已满，需要下沉",
+			capacity:     5,
+			data:         []int{1, 2, 3, 4, 5},
+			element:      6,
+			wantEvicted:  1,
+			wantDidEvict: true,
+			wantOrder:    []int{2, 3, 4, 5, 6},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(tc.capacity, tc.data, compare())
+			require.NotNil(t, q)
+			evicted, didEvict, err := q.EnqueueOrReplace(tc.element)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantEvicted, evicted)
+			assert.Equal(t, tc.wantDidEvict, didEvict)
+			assert.Equal(t, tc.wantOrder, q.AsSortedSlice())
+		})
+	}
+
+	t.Run("保留最大的 K 个元素", func(t *testing.T) {
+		q := NewPriorityQueue[int](3, compare())
+		for _, el := range []int{5, 1, 9, 3, 7, 2, 8} {
+			_, _, err := q.EnqueueOrReplace(el)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, []int{7, 8, 9}, q.AsSortedSlice())
+	})
+}
+
+func TestPriorityQueue_EnqueueAll(t *testing.T) {
+	testCases := []struct {
+		name      string
+		capacity  int
+		data      []int
+		items     []int
+		wantErr   error
+		wantOrder []int
+	}{
+		{
+			name:      "空队列",
+			data:      []int{},
+			items:     []int{6, 5, 4, 3, 2, 1},
+			wantOrder: []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:      "非空队列",
+			data:      []int{3, 8, 1},
+			items:     []int{6, 5, 4, 7, 2},
+			wantOrder: []int{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+		{
+			name:      "没有元素",
+			data:      []int{3, 1, 2},
+			items:     []int{},
+			wantOrder: []int{1, 2, 3},
+		},
+		{
+			name:      "有界队列，刚好填满",
+			capacity:  5,
+			data:      []int{3, 1},
+			items:     []int{5, 2, 4},
+			wantOrder: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:      "有界队列，超出容量",
+			capacity:  4,
+			data:      []int{3, 1},
+			items:     []int{5, 2, 4},
+			wantErr:   ErrOutOfCapacity,
+			wantOrder: []int{1, 3},
+		},
+		{
+			name:      "无界队列，超出初始容量",
+			data:      []int{3, 1},
+			items:     make([]int, 100),
+			wantOrder: append(make([]int, 100), 1, 3),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(tc.capacity, tc.data, compare())
+			require.NotNil(t, q)
+			err := q.EnqueueAll(tc.items)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, len(tc.wantOrder), q.Len())
+			res := make([]int, 0, q.Len())
+			for q.Len() > 0 {
+				el, err := q.Dequeue()
+				require.NoError(t, err)
+				res = append(res, el)
+			}
+			assert.Equal(t, tc.wantOrder, res)
+		})
+	}
+}
+
+func TestPriorityQueue_Merge(t *testing.T) {
+	testCases := []struct {
+		name      string
+		capacity  int
+		data      []int
+		other     []int
+		wantErr   error
+		wantOrder []int
+	}{
+		{
+			name:      "两个空队列",
+			data:      []int{},
+			other:     []int{},
+			wantOrder: []int{},
+		},
+		{
+			name:      "合并到空队列",
+			data:      []int{},
+			other:     []int{3, 1, 2},
+			wantOrder: []int{1, 2, 3},
+		},
+		{
+			name:      "合并空队列",
+			data:      []int{3, 1, 2},
+			other:     []int{},
+			wantOrder: []int{1, 2, 3},
+		},
+		{
+			name:      "交错的元素",
+			data:      []int{7, 1, 5, 3},
+			other:     []int{2, 8, 4, 6},
+			wantOrder: []int{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+		{
+			name:      "有界队列，刚好填满",
+			capacity:  4,
+			data:      []int{3, 1},
+			other:     []int{4, 2},
+			wantOrder: []int{1, 2, 3, 4},
+		},
+		{
+			name:      "有界队列，超出容量",
+			capacity:  3,
+			data:      []int{3, 1},
+			other:     []int{4, 2},
+			wantErr:   ErrOutOfCapacity,
+			wantOrder: []int{1, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(tc.capacity, tc.data, compare())
+			require.NotNil(t, q)
+			other := priorityQueueOf(0, tc.other, compare())
+			require.NotNil(t, other)
+			err := q.Merge(other)
+			assert.Equal(t, tc.wantErr, err)
+			// other 保持不变
+			assert.Equal(t, len(tc.other), other.Len())
+			assert.Equal(t, tc.wantOrder, q.AsSortedSlice())
+		})
+	}
+
+	t.Run("合并自己", func(t *testing.T) {
+		q := priorityQueueOf(0, []int{2, 1}, compare())
+		require.NoError(t, q.Merge(q))
+		assert.Equal(t, []int{1, 1, 2, 2}, q.AsSortedSlice())
+	})
+
+	t.Run("合并 nil", func(t *testing.T) {
+		q := priorityQueueOf(0, []int{2, 1}, compare())
+		require.NoError(t, q.Merge(nil))
+		assert.Equal(t, []int{1, 2}, q.AsSortedSlice())
+	})
+}
+
 func TestPriorityQueue_Dequeue(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -471,6 +760,759 @@ func TestPriorityQueue_Shrink(t *testing.T) {
 	}
 }
 
+func TestPriorityQueue_ShrinkPolicy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        []Option[int]
+		enqueueLoop int
+		dequeueLoop int
+		sliceCap    int
+	}{
+		{
+			name:        "默认策略",
+			enqueueLoop: 2000,
+			dequeueLoop: 1990,
+			sliceCap:    50,
+		},
+		{
+			name:        "不缩容",
+			opts:        []Option[int]{WithShrinkPolicy[int](slice.NoShrink)},
+			enqueueLoop: 2000,
+			dequeueLoop: 1990,
+			sliceCap:    2560,
+		},
+		{
+			name: "自定义策略",
+			opts: []Option[int]{
+				// 容量超过 1024 的时候才缩容，每次缩容到原来的 3/4
+				// 2560 -> 1920 -> 1440 -> 1080 -> 810
+				WithShrinkPolicy[int](slice.NewShrinkPolicy(0, 1024, 0.75, 1)),
+			},
+			enqueueLoop: 2000,
+			dequeueLoop: 1990,
+			sliceCap:    810,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewPriorityQueue[int](0, compare(), tc.opts...)
+			for i := 0; i < tc.enqueueLoop; i++ {
+				require.NoError(t, q.Enqueue(i))
+			}
+			// 副本使用相同的缩容策略
+			cp := q.Clone()
+			for i := 0; i < tc.dequeueLoop; i++ {
+				_, err := q.Dequeue()
+				require.NoError(t, err)
+				_, err = cp.Dequeue()
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.sliceCap, cap(q.data))
+			assert.Equal(t, tc.sliceCap, cap(cp.data))
+		})
+	}
+}
+
+func TestPriorityQueue_RemoveShrink(t *testing.T) {
+	testCases := []struct {
+		name       string
+		capacity   int
+		opts       []Option[int]
+		enqueueNum int
+		removeNum  int
+		sliceCap   int
+	}{
+		{
+			name:       "默认策略",
+			enqueueNum: 2000,
+			removeNum:  1990,
+			sliceCap:   50,
+		},
+		{
+			name:       "不缩容",
+			opts:       []Option[int]{WithShrinkPolicy[int](slice.NoShrink)},
+			enqueueNum: 2000,
+			removeNum:  1990,
+			sliceCap:   2560,
+		},
+		{
+			name: "自定义策略",
+			opts: []Option[int]{
+				WithShrinkPolicy[int](slice.NewShrinkPolicy(0, 1024, 0.75, 1)),
+			},
+			enqueueNum: 2000,
+			removeNum:  1990,
+			sliceCap:   810,
+		},
+		{
+			name:       "有界队列不缩容",
+			capacity:   2000,
+			enqueueNum: 2000,
+			removeNum:  1990,
+			sliceCap:   2001,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewPriorityQueue[int](tc.capacity, compare(), tc.opts...)
+			for i := 0; i < tc.enqueueNum; i++ {
+				require.NoError(t, q.Enqueue(i))
+			}
+			// 删除最大的元素，而不是堆顶，确保走的是 Remove 的路径
+			for i := tc.enqueueNum - 1; i >= tc.enqueueNum-tc.removeNum; i-- {
+				_, ok := q.Remove(func(el int) bool {
+					return el == i
+				})
+				require.True(t, ok)
+			}
+			assert.Equal(t, tc.sliceCap, cap(q.data))
+			assert.Equal(t, tc.enqueueNum-tc.removeNum, q.Len())
+			// 缩容之后依旧满足堆的性质
+			want := make([]int, 0, q.Len())
+			for i := 0; i < q.Len(); i++ {
+				want = append(want, i)
+			}
+			assert.Equal(t, want, q.AsSortedSlice())
+		})
+	}
+}
+
+func TestWithInitialCap(t *testing.T) {
+	testCases := []struct {
+		name       string
+		capacity   int
+		initialCap int
+		sliceCap   int
+	}{
+		{
+			name:     "无界，默认",
+			sliceCap: 64,
+		},
+		{
+			name:       "无界，预分配",
+			initialCap: 1000,
+			sliceCap:   1001,
+		},
+		{
+			name:       "无界，initialCap 不合法",
+			initialCap: -1,
+			sliceCap:   64,
+		},
+		{
+			name:       "有界，没有影响",
+			capacity:   10,
+			initialCap: 1000,
+			sliceCap:   11,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewPriorityQueue[int](tc.capacity, compare(), WithInitialCap[int](tc.initialCap))
+			assert.Equal(t, tc.sliceCap, cap(q.data))
+			assert.Equal(t, 0, q.Len())
+			assert.Equal(t, tc.capacity, q.Cap())
+		})
+	}
+
+	// 预分配之后，入队不会触发扩容
+	q := NewPriorityQueue[int](0, compare(), WithInitialCap[int](1000))
+	for i := 1000; i > 0; i-- {
+		require.NoError(t, q.Enqueue(i))
+	}
+	assert.Equal(t, 1001, cap(q.data))
+	assert.Equal(t, []int{1, 2, 3}, q.PeekN(3))
+}
+
+func TestPriorityQueue_DequeueLessThan(t *testing.T) {
+	testCases := []struct {
+		name      string
+		data      []int
+		threshold int
+		want      []int
+		wantOrder []int
+	}{
+		{
+			name:      "空队列",
+			data:      []int{},
+			threshold: 10,
+			want:      []int{},
+			wantOrder: []int{},
+		},
+		{
+			name:      "没有满足条件的元素",
+			data:      []int{5, 3, 4},
+			threshold: 3,
+			want:      []int{},
+			wantOrder: []int{3, 4, 5},
+		},
+		{
+			name:      "部分元素",
+			data:      []int{5, 1, 4, 2, 3},
+			threshold: 3,
+			want:      []int{1, 2},
+			wantOrder: []int{3, 4, 5},
+		},
+		{
+			name:      "相等的元素不会出队",
+			data:      []int{2, 1, 2, 3},
+			threshold: 2,
+			want:      []int{1},
+			wantOrder: []int{2, 2, 3},
+		},
+		{
+			name:      "所有元素",
+			data:      []int{5, 1, 4},
+			threshold: 10,
+			want:      []int{1, 4, 5},
+			wantOrder: []int{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(0, tc.data, compare())
+			require.NotNil(t, q)
+			assert.Equal(t, tc.want, q.DequeueLessThan(tc.threshold))
+			assert.Equal(t, tc.wantOrder, q.AsSortedSlice())
+		})
+	}
+}
+
+func TestPriorityQueue_Remove(t *testing.T) {
+	testCases := []struct {
+		name      string
+		data      []int
+		target    int
+		wantVal   int
+		wantOk    bool
+		wantSlice []int
+		wantOrder []int
+	}{
+		{
+			name:      "空队列",
+			data:      []int{},
+			target:    1,
+			wantSlice: []int{0},
+			wantOrder: []int{},
+		},
+		{
+			name:      "没有匹配的元素",
+			data:      []int{1, 10, 2},
+			target:    100,
+			wantSlice: []int{0, 1, 10, 2},
+			wantOrder: []int{1, 2, 10},
+		},
+		{
+			name:      "删除堆顶",
+			data:      []int{1, 10, 2, 11, 12, 3, 4},
+			target:    1,
+			wantVal:   1,
+			wantOk:    true,
+			wantSlice: []int{0, 2, 10, 3, 11, 12, 4},
+			wantOrder: []int{2, 3, 4, 10, 11, 12},
+		},
+		{
+			name:      "删除中间元素，需要下沉",
+			data:      []int{1, 10, 2, 11, 12, 3, 4},
+			target:    2,
+			wantVal:   2,
+			wantOk:    true,
+			wantSlice: []int{0, 1, 10, 3, 11, 12, 4},
+			wantOrder: []int{1, 3, 4, 10, 11, 12},
+		},
+		{
+			name:      "删除中间元素，需要上浮",
+			data:      []int{1, 10, 2, 11, 12, 3, 4},
+			target:    11,
+			wantVal:   11,
+			wantOk:    true,
+			wantSlice: []int{0, 1, 4, 2, 10, 12, 3},
+			wantOrder: []int{1, 2, 3, 4, 10, 12},
+		},
+		{
+			name:      "删除最后一个元素",
+			data:      []int{1, 10, 2, 11, 12, 3, 4},
+			target:    4,
+			wantVal:   4,
+			wantOk:    true,
+			wantSlice: []int{0, 1, 10, 2, 11, 12, 3},
+			wantOrder: []int{1, 2, 3, 10, 11, 12},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(0, tc.data, compare())
+			require.NotNil(t, q)
+			val, ok := q.Remove(func(el int) bool {
+				return el == tc.target
+			})
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantVal, val)
+			assert.Equal(t, tc.wantSlice, q.data)
+			// 删除之后，依旧满足堆的性质
+			res := make([]int, 0, q.Len())
+			for q.Len() > 0 {
+				el, err := q.Dequeue()
+				require.NoError(t, err)
+				res = append(res, el)
+			}
+			assert.Equal(t, tc.wantOrder, res)
+		})
+	}
+}
+
+func TestPriorityQueue_Update(t *testing.T) {
+	testCases := []struct {
+		name      string
+		data      []int
+		target    int
+		newVal    int
+		wantOk    bool
+		wantSlice []int
+		wantOrder []int
+	}{
+		{
+			name:      "空队列",
+			data:      []int{},
+			target:    1,
+			newVal:    2,
+			wantSlice: []int{0},
+			wantOrder: []int{},
+		},
+		{
+			name:      "没有匹配的元素",
+			data:      []int{1, 10, 2},
+			target:    100,
+			newVal:    0,
+			wantSlice: []int{0, 1, 10, 2},
+			wantOrder: []int{1, 2, 10},
+		},
+		{
+			name:      "变小，上浮到堆顶",
+			data:      []int{1, 10, 2, 11, 12, 3, 4},
+			target:    11,
+			newVal:    0,
+			wantOk:    true,
+			wantSlice: []int{0, 0, 1, 2, 10, 12, 3, 4},
+			wantOrder: []int{0, 1, 2, 3, 4, 10, 12},
+		},
+		{
+			name:      "变小，上浮到中间",
+			data:      []int{1, 10, 2, 11, 12, 3, 4},
+			target:    12,
+			newVal:    5,
+			wantOk:    true,
+			wantSlice: []int{0, 1, 5, 2, 11, 10, 3, 4},
+			wantOrder: []int{1, 2, 3, 4, 5, 10, 11},
+		},
+		{
+			name:      "变大，下沉",
+			data:      []int{1, 10, 2, 11, 12, 3, 4},
+			target:    1,
+			newVal:    13,
+			wantOk:    true,
+			wantSlice: []int{0, 2, 10, 3, 11, 12, 13, 4},
+			wantOrder: []int{2, 3, 4, 10, 11, 12, 13},
+		},
+		{
+			name:      "不变",
+			data:      []int{1, 10, 2, 11, 12, 3, 4},
+			target:    10,
+			newVal:    10,
+			wantOk:    true,
+			wantSlice: []int{0, 1, 10, 2, 11, 12, 3, 4},
+			wantOrder: []int{1, 2, 3, 4, 10, 11, 12},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(0, tc.data, compare())
+			require.NotNil(t, q)
+			ok := q.Update(func(el int) bool {
+				return el == tc.target
+			}, tc.newVal)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantSlice, q.data)
+			// 更新之后，依旧满足堆的性质
+			assert.Equal(t, tc.wantOrder, q.AsSortedSlice())
+		})
+	}
+}
+
+func TestPriorityQueue_Iterate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     []int
+		stopAt   int
+		wantIdxs []int
+		wantVals []int
+	}{
+		{
+			name:     "空队列",
+			data:     []int{},
+			stopAt:   -1,
+			wantIdxs: []int{},
+			wantVals: []int{},
+		},
+		{
+			name:     "按照堆的存储顺序遍历",
+			data:     []int{6, 5, 4, 3, 2, 1},
+			stopAt:   -1,
+			wantIdxs: []int{0, 1, 2, 3, 4, 5},
+			wantVals: []int{1, 3, 2, 6, 4, 5},
+		},
+		{
+			name:     "中断遍历",
+			data:     []int{6, 5, 4, 3, 2, 1},
+			stopAt:   2,
+			wantIdxs: []int{0, 1, 2},
+			wantVals: []int{1, 3, 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(0, tc.data, compare())
+			require.NotNil(t, q)
+			idxs := make([]int, 0, q.Len())
+			vals := make([]int, 0, q.Len())
+			q.Iterate(func(idx int, val int) bool {
+				idxs = append(idxs, idx)
+				vals = append(vals, val)
+				return idx != tc.stopAt
+			})
+			assert.Equal(t, tc.wantIdxs, idxs)
+			assert.Equal(t, tc.wantVals, vals)
+			// 遍历不会修改队列
+			assert.Equal(t, len(tc.data), q.Len())
+		})
+	}
+}
+
+func TestPriorityQueue_Contains(t *testing.T) {
+	testCases := []struct {
+		name   string
+		data   []int
+		target int
+		want   bool
+	}{
+		{
+			name:   "空队列",
+			data:   []int{},
+			target: 1,
+		},
+		{
+			name:   "堆顶",
+			data:   []int{1, 10, 2, 11, 12, 3, 4},
+			target: 1,
+			want:   true,
+		},
+		{
+			name:   "最后一个元素",
+			data:   []int{1, 10, 2, 11, 12, 3, 4},
+			target: 4,
+			want:   true,
+		},
+		{
+			name:   "不存在",
+			data:   []int{1, 10, 2, 11, 12, 3, 4},
+			target: 100,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(0, tc.data, compare())
+			require.NotNil(t, q)
+			before := append([]int(nil), q.data...)
+			assert.Equal(t, tc.want, q.Contains(func(el int) bool {
+				return el == tc.target
+			}))
+			// 查找不会修改队列
+			assert.Equal(t, before, q.data)
+		})
+	}
+}
+
+func TestPriorityQueue_PeekN(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []int
+		n    int
+		want []int
+	}{
+		{
+			name: "空队列",
+			data: []int{},
+			n:    3,
+			want: []int{},
+		},
+		{
+			name: "n 小于等于 0",
+			data: []int{6, 5, 4},
+			n:    0,
+			want: []int{},
+		},
+		{
+			name: "n 小于队列长度",
+			data: []int{6, 5, 4, 3, 2, 1},
+			n:    3,
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "n 大于队列长度",
+			data: []int{6, 5, 4, 3, 2, 1},
+			n:    10,
+			want: []int{1, 2, 3, 4, 5, 6},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(0, tc.data, compare())
+			require.NotNil(t, q)
+			before := make([]int, len(q.data))
+			copy(before, q.data)
+			assert.Equal(t, tc.want, q.PeekN(tc.n))
+			// 原来的堆没有被修改
+			assert.Equal(t, before, q.data)
+		})
+	}
+}
+
+func TestPriorityQueue_Resize(t *testing.T) {
+	testCases := []struct {
+		name      string
+		capacity  int
+		data      []int
+		newCap    int
+		wantErr   error
+		wantCap   int
+		boundless bool
+		sliceCap  int
+	}{
+		{
+			name:     "有界队列扩容",
+			capacity: 6,
+			data:     []int{6, 5, 4, 3, 2, 1},
+			newCap:   10,
+			wantCap:  10,
+			sliceCap: 11,
+		},
+		{
+			name:     "有界队列缩容",
+			capacity: 10,
+			data:     []int{6, 5, 4},
+			newCap:   3,
+			wantCap:  3,
+			sliceCap: 4,
+		},
+		{
+			name:     "缩容到小于元素个数",
+			capacity: 10,
+			data:     []int{6, 5, 4},
+			newCap:   2,
+			wantErr:  ErrOutOfCapacity,
+			wantCap:  10,
+			sliceCap: 11,
+		},
+		{
+			name:      "有界队列变为无界队列",
+			capacity:  6,
+			data:      []int{6, 5, 4, 3, 2, 1},
+			newCap:    0,
+			wantCap:   0,
+			boundless: true,
+			sliceCap:  7,
+		},
+		{
+			name:     "无界队列变为有界队列",
+			capacity: 0,
+			data:     []int{6, 5, 4},
+			newCap:   5,
+			wantCap:  5,
+			sliceCap: 6,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(tc.capacity, tc.data, compare())
+			require.NotNil(t, q)
+			err := q.Resize(tc.newCap)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCap, q.Cap())
+			assert.Equal(t, tc.boundless, q.IsBoundless())
+			assert.Equal(t, tc.sliceCap, cap(q.data))
+			assert.Equal(t, len(tc.data), q.Len())
+			// 调整之后依旧满足堆的性质，并且遵守新的容量限制
+			for q.Len() < q.Cap() {
+				require.NoError(t, q.Enqueue(0))
+			}
+			if !q.IsBoundless() {
+				assert.Equal(t, ErrOutOfCapacity, q.Enqueue(0))
+			}
+			prev := -1
+			for q.Len() > 0 {
+				el, err := q.Dequeue()
+				require.NoError(t, err)
+				assert.LessOrEqual(t, prev, el)
+				prev = el
+			}
+		})
+	}
+}
+
+func TestPriorityQueue_Clear(t *testing.T) {
+	testCases := []struct {
+		name     string
+		capacity int
+		data     []int
+	}{
+		{
+			name:     "有界空队列",
+			capacity: 10,
+			data:     []int{},
+		},
+		{
+			name:     "有界非空队列",
+			capacity: 10,
+			data:     []int{6, 5, 4, 3, 2, 1},
+		},
+		{
+			name:     "无界非空队列",
+			capacity: 0,
+			data:     []int{6, 5, 4, 3, 2, 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(tc.capacity, tc.data, compare())
+			require.NotNil(t, q)
+			sliceCap := cap(q.data)
+			q.Clear()
+			assert.Equal(t, 0, q.Len())
+			assert.Equal(t, tc.capacity, q.Cap())
+			assert.Equal(t, sliceCap, cap(q.data))
+			_, err := q.Peek()
+			assert.Equal(t, ErrEmptyQueue, err)
+			// 清空之后可以继续使用
+			require.NoError(t, q.Enqueue(3))
+			require.NoError(t, q.Enqueue(1))
+			val, err := q.Dequeue()
+			require.NoError(t, err)
+			assert.Equal(t, 1, val)
+		})
+	}
+}
+
+func TestPriorityQueue_AsSortedSlice(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []int
+		want []int
+	}{
+		{
+			name: "空队列",
+			data: []int{},
+			want: []int{},
+		},
+		{
+			name: "有重复元素",
+			data: []int{6, 5, 6, 3, 2, 3},
+			want: []int{2, 3, 3, 5, 6, 6},
+		},
+		{
+			name: "无重复元素",
+			data: []int{6, 5, 4, 3, 2, 1},
+			want: []int{1, 2, 3, 4, 5, 6},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(0, tc.data, compare())
+			require.NotNil(t, q)
+			res := q.AsSortedSlice()
+			assert.Equal(t, tc.want, res)
+			// 原来的队列不受影响，返回的切片也是独立的
+			assert.Equal(t, len(tc.data), q.Len())
+			if len(res) > 0 {
+				res[0] = -1
+			}
+			assert.Equal(t, tc.want, q.AsSortedSlice())
+		})
+	}
+}
+
+func TestPriorityQueue_String(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []int
+		want string
+	}{
+		{
+			name: "空队列",
+			data: []int{},
+			want: "[]",
+		},
+		{
+			name: "一个元素",
+			data: []int{1},
+			want: "[1]",
+		},
+		{
+			name: "多个元素，按照堆的存储顺序",
+			data: []int{3, 1, 2},
+			want: "[1 3 2]",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(0, tc.data, compare())
+			require.NotNil(t, q)
+			assert.Equal(t, tc.want, q.String())
+			assert.Equal(t, tc.want, fmt.Sprint(q))
+		})
+	}
+}
+
+func TestPriorityQueue_Clone(t *testing.T) {
+	testCases := []struct {
+		name     string
+		capacity int
+		data     []int
+	}{
+		{
+			name:     "空队列",
+			capacity: 0,
+			data:     []int{},
+		},
+		{
+			name:     "有界队列",
+			capacity: 6,
+			data:     []int{6, 5, 4, 3, 2, 1},
+		},
+		{
+			name:     "无界队列",
+			capacity: 0,
+			data:     []int{6, 5, 4, 3, 2, 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := priorityQueueOf(tc.capacity, tc.data, compare())
+			require.NotNil(t, q)
+			cp := q.Clone()
+			assert.Equal(t, q.data, cp.data)
+			assert.Equal(t, q.Cap(), cp.Cap())
+			assert.Equal(t, cap(q.data), cap(cp.data))
+
+			// 修改副本不会影响原来的队列
+			before := make([]int, len(q.data))
+			copy(before, q.data)
+			_, _ = cp.Dequeue()
+			_, _ = cp.Dequeue()
+			_ = cp.Enqueue(100)
+			assert.Equal(t, before, q.data)
+		})
+	}
+}
+
 func priorityQueueOf(capacity int, data []int, compare generic.Comparator[int]) *PriorityQueue[int] {
 	q := NewPriorityQueue[int](capacity, compare)
 	for _, el := range data {
@@ -23,27 +23,88 @@ func calCapacity(c, l int) (int, bool) {
 	if c <= 64 {
 		return c, false
 	}
+	// 这里用乘法而不是除法，避免 len 为 0 的时候除零 panic
 	// 容量>2048，并且已使用的长度len没有达到cap的一半，缩容到原来的5/8
-	if c > 2048 && (c/l >= 2) {
+	if c > 2048 && (c >= 2*l) {
 		factor := 0.625
 		return int(float32(c) * float32(factor)), true
 	}
 	// 64<容量<=2048，并且已使用的长度len没有达到cap的1/4，缩容到原来的1/2
-	if c <= 2048 && (c/l >= 4) {
+	if c <= 2048 && (c >= 4*l) {
 		return c / 2, true
 	}
 	return c, false
 }
 
+// ShrinkPolicy 缩容策略，c 是切片的容量，l 是切片的长度
+// 返回缩容之后的容量，以及是否需要缩容
+type ShrinkPolicy func(c, l int) (int, bool)
+
+// DefaultShrinkPolicy 默认的缩容策略，Shrink 使用的就是这个策略
+// 等价于 NewShrinkPolicy(64, 2048, 0.625, 0.5)
+func DefaultShrinkPolicy(c, l int) (int, bool) {
+	return calCapacity(c, l)
+}
+
+// NoShrink 永远不缩容
+func NoShrink(c, _ int) (int, bool) {
+	return c, false
+}
+
+// NewShrinkPolicy 创建一个缩容策略
+// 容量 <= minCap 的时候不缩容；
+// 容量 > largeCap 并且长度没有达到容量的一半时，缩容到原来的 largeFactor 倍；
+// minCap < 容量 <= largeCap 并且长度没有达到容量的 1/4 时，缩容到原来的 smallFactor 倍
+// 计算出来的容量不小于原来的容量时，认为不需要缩容，例如 factor >= 1
+func NewShrinkPolicy(minCap, largeCap int, largeFactor, smallFactor float64) ShrinkPolicy {
+	return func(c, l int) (int, bool) {
+		if c <= minCap {
+			return c, false
+		}
+		factor := 1.0
+		if c > largeCap && c >= 2*l {
+			factor = largeFactor
+		} else if c <= largeCap && c >= 4*l {
+			factor = smallFactor
+		}
+		if n := int(float64(c) * factor); n < c {
+			return n, true
+		}
+		return c, false
+	}
+}
+
+// Shrink 使用默认的缩容策略对切片进行缩容
 func Shrink[T any](src []T) []T {
+	return ShrinkWithPolicy[T](src, calCapacity)
+}
+
+// ShrinkWithPolicy 使用 policy 对切片进行缩容
+// 需要缩容的时候会创建一个新的切片，并将原来的数据拷贝过去；否则直接返回 src
+// 返回的切片容量永远不会小于 len(src)，也不会大于 cap(src)
+func ShrinkWithPolicy[T any](src []T, policy ShrinkPolicy) []T {
 	// 获取长度len和容量cap
 	c, l := cap(src), len(src)
-	n, changed := calCapacity(c, l)
+	n, changed := policy(c, l)
 	if !changed {
 		return src
 	}
+	// 缩容之后的容量不能小于长度，否则 append 的时候会立刻扩容，缩容也就没有意义了
+	// 默认策略不会出现这种情况，但是自定义的策略可能会
+	n = max(n, l)
+	if n >= c {
+		return src
+	}
 	// 重新创建一个切片，将原来的切片数据拷贝到新的切片中
 	s := make([]T, 0, n)
 	s = append(s, src...)
 	return s
 }
+
+// ShrinkToFit 返回一个容量和长度相等的新切片，释放所有多余的容量
+// 和 Shrink 不同，它不考虑任何阈值，总是会重新分配内存
+func ShrinkToFit[T any](src []T) []T {
+	res := make([]T, len(src))
+	copy(res, src)
+	return res
+}
@@ -57,6 +57,18 @@ func TestShrink(t *testing.T) {
 			enqueueLoop: 2000,
 			expectCap:   3000,
 		},
+		{
+			name:        "小于2048, 空切片",
+			originCap:   1000,
+			enqueueLoop: 0,
+			expectCap:   500,
+		},
+		{
+			name:        "大于2048，空切片",
+			originCap:   3000,
+			enqueueLoop: 0,
+			expectCap:   1875,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -70,3 +82,199 @@ func TestShrink(t *testing.T) {
 		})
 	}
 }
+
+func TestShrinkWithPolicy(t *testing.T) {
+	testCases := []struct {
+		name      string
+		originCap int
+		length    int
+		policy    ShrinkPolicy
+		expectCap int
+	}{
+		{
+			name:      "不缩容",
+			originCap: 1000,
+			length:    10,
+			policy:    NoShrink,
+			expectCap: 1000,
+		},
+		{
+			name:      "自定义，小于 minCap",
+			originCap: 100,
+			length:    10,
+			policy:    NewShrinkPolicy(128, 1024, 0.75, 0.25),
+			expectCap: 100,
+		},
+		{
+			name:      "自定义，小于 largeCap，不足1/4",
+			originCap: 1000,
+			length:    10,
+			policy:    NewShrinkPolicy(128, 1024, 0.75, 0.25),
+			expectCap: 250,
+		},
+		{
+			name:      "自定义，大于 largeCap，不足一半",
+			originCap: 2000,
+			length:    10,
+			policy:    NewShrinkPolicy(128, 1024, 0.75, 0.25),
+			expectCap: 1500,
+		},
+		{
+			name:      "自定义，factor 不小于 1",
+			originCap: 1000,
+			length:    10,
+			policy:    NewShrinkPolicy(128, 1024, 1, 1),
+			expectCap: 1000,
+		},
+		{
+			name:      "自定义，大于 largeCap，超过一半",
+			originCap: 2000,
+			length:    1500,
+			policy:    NewShrinkPolicy(128, 1024, 0.75, 0.25),
+			expectCap: 2000,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := make([]int, tc.length, tc.originCap)
+			for i := range l {
+				l[i] = i
+			}
+			res := ShrinkWithPolicy[int](l, tc.policy)
+			assert.Equal(t, tc.expectCap, cap(res))
+			assert.Equal(t, l, res)
+		})
+	}
+}
+
+func TestDefaultShrinkPolicy(t *testing.T) {
+	// 默认策略必须和 NewShrinkPolicy(64, 2048, 0.625, 0.5) 完全一致
+	policy := NewShrinkPolicy(64, 2048, 0.625, 0.5)
+	for c := 0; c <= 5000; c++ {
+		for _, l := range []int{0, 1, c / 8, c/4 - 1, c / 4, c/4 + 1, c/2 - 1, c / 2, c/2 + 1, c} {
+			if l < 0 {
+				continue
+			}
+			wantCap, wantChanged := DefaultShrinkPolicy(c, l)
+			gotCap, gotChanged := policy(c, l)
+			assert.Equal(t, wantCap, gotCap, "c=%d, l=%d", c, l)
+			assert.Equal(t, wantChanged, gotChanged, "c=%d, l=%d", c, l)
+		}
+	}
+}
+
+func TestShrinkWithPolicy_Guard(t *testing.T) {
+	testCases := []struct {
+		name      string
+		originCap int
+		length    int
+		policy    ShrinkPolicy
+		expectCap int
+	}{
+		{
+			name:      "缩容之后小于长度",
+			originCap: 1000,
+			length:    500,
+			policy: func(c, l int) (int, bool) {
+				return 10, true
+			},
+			expectCap: 500,
+		},
+		{
+			name:      "缩容之后为负数",
+			originCap: 1000,
+			length:    0,
+			policy: func(c, l int) (int, bool) {
+				return -1, true
+			},
+			expectCap: 0,
+		},
+		{
+			name:      "缩容之后大于原容量",
+			originCap: 1000,
+			length:    10,
+			policy: func(c, l int) (int, bool) {
+				return c * 2, true
+			},
+			expectCap: 1000,
+		},
+		{
+			name:      "默认策略，刚好超过 64，长度刚好是 1/4",
+			originCap: 65,
+			length:    16,
+			policy:    DefaultShrinkPolicy,
+			expectCap: 32,
+		},
+		{
+			name:      "默认策略，刚好超过 2048，长度刚好是一半",
+			originCap: 2050,
+			length:    1025,
+			policy:    DefaultShrinkPolicy,
+			expectCap: 1281,
+		},
+		{
+			name:      "自定义策略，factor 过小",
+			originCap: 3000,
+			length:    1500,
+			policy:    NewShrinkPolicy(64, 2048, 0.1, 0.1),
+			expectCap: 1500,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := make([]int, tc.length, tc.originCap)
+			for i := range l {
+				l[i] = i
+			}
+			res := ShrinkWithPolicy[int](l, tc.policy)
+			assert.Equal(t, tc.expectCap, cap(res))
+			assert.Equal(t, l, res)
+		})
+	}
+}
+
+func TestShrinkToFit(t *testing.T) {
+	testCases := []struct {
+		name      string
+		originCap int
+		length    int
+	}{
+		{
+			name: "空切片",
+		},
+		{
+			name:      "长度为 0",
+			originCap: 100,
+		},
+		{
+			name:      "容量很小",
+			originCap: 10,
+			length:    5,
+		},
+		{
+			name:      "容量很大",
+			originCap: 10000,
+			length:    5000,
+		},
+		{
+			name:      "容量和长度相等",
+			originCap: 10,
+			length:    10,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := make([]int, tc.length, tc.originCap)
+			for i := range l {
+				l[i] = i
+			}
+			res := ShrinkToFit[int](l)
+			assert.Equal(t, tc.length, cap(res))
+			assert.Equal(t, l, res)
+			if tc.length > 0 {
+				// 总是一个新的切片
+				assert.NotSame(t, &l[0], &res[0])
+			}
+		})
+	}
+}
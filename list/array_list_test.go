@@ -59,14 +59,14 @@ func TestArrayList_Add(t *testing.T) {
 			list:    NewArrayListOf[int]([]int{1, 2, 3}),
 			newVal:  100,
 			index:   -1,
-			wantErr: fmt.Errorf("ekit: 下标超出范围，长度 %d, 下标 %d", 3, -1),
+			wantErr: errs.NewErrIndexOutOfRange(3, -1),
 		},
 		{
 			name:    "add num to index OutOfRange",
 			list:    NewArrayListOf[int]([]int{1, 2, 3}),
 			newVal:  100,
 			index:   4,
-			wantErr: fmt.Errorf("ekit: 下标超出范围，长度 %d, 下标 %d", 3, 4),
+			wantErr: errs.NewErrIndexOutOfRange(3, 4),
 		},
 	}
 
@@ -429,14 +429,14 @@ func TestArrayList_Get(t *testing.T) {
 			list:    NewArrayListOf[int]([]int{123, 100}),
 			index:   2,
 			wantVal: 0,
-			wantErr: fmt.Errorf("ekit: 下标超出范围，长度 %d, 下标 %d", 2, 2),
+			wantErr: errs.NewErrIndexOutOfRange(2, 2),
 		},
 		{
 			name:    "index -1",
 			list:    NewArrayListOf[int]([]int{123, 100}),
 			index:   -1,
 			wantVal: 0,
-			wantErr: fmt.Errorf("ekit: 下标超出范围，长度 %d, 下标 %d", 2, -1),
+			wantErr: errs.NewErrIndexOutOfRange(2, -1),
 		},
 	}
 
@@ -540,7 +540,7 @@ func TestArrayList_Set(t *testing.T) {
 			index:     -1,
 			newVal:    5,
 			wantSlice: []int{},
-			wantErr:   fmt.Errorf("ekit: 下标超出范围，长度 %d, 下标 %d", 5, -1),
+			wantErr:   errs.NewErrIndexOutOfRange(5, -1),
 		},
 		{
 			name:      "index  100",
@@ -548,7 +548,7 @@ func TestArrayList_Set(t *testing.T) {
 			index:     100,
 			newVal:    5,
 			wantSlice: []int{},
-			wantErr:   fmt.Errorf("ekit: 下标超出范围，长度 %d, 下标 %d", 5, 100),
+			wantErr:   errs.NewErrIndexOutOfRange(5, 100),
 		},
 	}
 
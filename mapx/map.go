@@ -0,0 +1,73 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mapx
+
+import "github.com/go-generic"
+
+// Keys 返回 map 中的所有 key，顺序不固定
+// 即使传入的map为nil，也保证返回一个空切片而不是nil
+func Keys[K comparable, V any](m map[K]V) []K {
+	res := make([]K, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	return res
+}
+
+// Values 返回 map 中的所有 value，顺序不固定
+// 即使传入的map为nil，也保证返回一个空切片而不是nil
+func Values[K comparable, V any](m map[K]V) []V {
+	res := make([]V, 0, len(m))
+	for _, v := range m {
+		res = append(res, v)
+	}
+	return res
+}
+
+// Entries 返回 map 中的所有键值对，顺序不固定
+// 即使传入的map为nil，也保证返回一个空切片而不是nil
+func Entries[K comparable, V any](m map[K]V) []generic.Pair[K, V] {
+	res := make([]generic.Pair[K, V], 0, len(m))
+	for k, v := range m {
+		res = append(res, generic.NewPair(k, v))
+	}
+	return res
+}
+
+// MapValues 使用 fn 转化 map 中的每一个 value，key 保持不变
+// 即使传入的map为nil，也保证返回一个空map而不是nil，fn 也不会被调用
+func MapValues[K comparable, V any, R any](m map[K]V, fn func(key K, val V) R) map[K]R {
+	res := make(map[K]R, len(m))
+	for k, v := range m {
+		res[k] = fn(k, v)
+	}
+	return res
+}
+
+// MapKeys 使用 fn 将 map 中的每一个 key 转化为新的 key，value 保持不变
+//
+// 注意:
+// 和 slice.ToMapV 一样，如果多个 key 被转化成同一个新 key，后写入的 value 会覆盖先写入的。
+// 但是 map 的遍历顺序是不固定的，所以最终保留的是哪一个 value 也是不确定的，
+// 如果需要确定的结果，请保证 fn 不会产生冲突
+//
+// 即使传入的map为nil，也保证返回一个空map而不是nil，fn 也不会被调用
+func MapKeys[K comparable, V any, R comparable](m map[K]V, fn func(key K, val V) R) map[R]V {
+	res := make(map[R]V, len(m))
+	for k, v := range m {
+		res[fn(k, v)] = v
+	}
+	return res
+}
@@ -0,0 +1,216 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mapx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeys(t *testing.T) {
+	testCases := []struct {
+		name string
+		m    map[string]int
+		want []string
+	}{
+		{
+			name: "nil",
+			want: []string{},
+		},
+		{
+			name: "empty",
+			m:    map[string]int{},
+			want: []string{},
+		},
+		{
+			name: "multiple",
+			m:    map[string]int{"a": 1, "b": 2, "c": 3},
+			want: []string{"a", "b", "c"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Keys(tc.m)
+			assert.NotNil(t, res)
+			assert.ElementsMatch(t, tc.want, res)
+		})
+	}
+}
+
+func TestValues(t *testing.T) {
+	testCases := []struct {
+		name string
+		m    map[string]int
+		want []int
+	}{
+		{
+			name: "nil",
+			want: []int{},
+		},
+		{
+			name: "empty",
+			m:    map[string]int{},
+			want: []int{},
+		},
+		{
+			name: "duplicate values",
+			m:    map[string]int{"a": 1, "b": 2, "c": 1},
+			want: []int{1, 1, 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Values(tc.m)
+			assert.NotNil(t, res)
+			assert.ElementsMatch(t, tc.want, res)
+		})
+	}
+}
+
+func TestEntries(t *testing.T) {
+	testCases := []struct {
+		name string
+		m    map[string]int
+		want []generic.Pair[string, int]
+	}{
+		{
+			name: "nil",
+			want: []generic.Pair[string, int]{},
+		},
+		{
+			name: "empty",
+			m:    map[string]int{},
+			want: []generic.Pair[string, int]{},
+		},
+		{
+			name: "multiple",
+			m:    map[string]int{"a": 1, "b": 2},
+			want: []generic.Pair[string, int]{
+				generic.NewPair("a", 1),
+				generic.NewPair("b", 2),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Entries(tc.m)
+			assert.NotNil(t, res)
+			assert.ElementsMatch(t, tc.want, res)
+		})
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	testCases := []struct {
+		name string
+		m    map[string]int
+		want map[string]string
+	}{
+		{
+			name: "nil",
+			want: map[string]string{},
+		},
+		{
+			name: "empty",
+			m:    map[string]int{},
+			want: map[string]string{},
+		},
+		{
+			name: "multiple",
+			m:    map[string]int{"a": 1, "b": 2},
+			want: map[string]string{"a": "a=1", "b": "b=2"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := MapValues(tc.m, func(key string, val int) string {
+				return fmt.Sprintf("%s=%d", key, val)
+			})
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	testCases := []struct {
+		name string
+		m    map[string]int
+		fn   func(key string, val int) string
+		want map[string]int
+	}{
+		{
+			name: "nil",
+			fn: func(key string, val int) string {
+				panic("fn 不应该被调用")
+			},
+			want: map[string]int{},
+		},
+		{
+			name: "multiple",
+			m:    map[string]int{"a": 1, "b": 2},
+			fn: func(key string, val int) string {
+				return key + key
+			},
+			want: map[string]int{"aa": 1, "bb": 2},
+		},
+		{
+			name: "collision with same value",
+			m:    map[string]int{"a": 1, "A": 1, "b": 2},
+			fn: func(key string, val int) string {
+				return strings.ToLower(key)
+			},
+			want: map[string]int{"a": 1, "b": 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := MapKeys(tc.m, tc.fn)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+
+	t.Run("collision", func(t *testing.T) {
+		res := MapKeys(map[string]int{"a": 1, "A": 2}, func(key string, val int) string {
+			return strings.ToLower(key)
+		})
+		assert.Len(t, res, 1)
+		assert.Contains(t, []int{1, 2}, res["a"])
+	})
+}
+
+func ExampleMapValues() {
+	res := MapValues(map[string]int{"a": 1}, func(key string, val int) int {
+		return val * 10
+	})
+	fmt.Println(res)
+	// Output:
+	// map[a:10]
+}
+
+func ExampleEntries() {
+	entries := Entries(map[string]int{"a": 1, "b": 2})
+	// map 的遍历顺序不固定，排序之后再输出
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+	fmt.Println(entries)
+	// Output:
+	// [<a, 1> <b, 2>]
+}
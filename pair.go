@@ -0,0 +1,36 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import "fmt"
+
+// Pair 键值对，也可以用来表示任意两个相关联的值
+type Pair[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// NewPair 创建一个键值对
+func NewPair[K any, V any](key K, value V) Pair[K, V] {
+	return Pair[K, V]{
+		Key:   key,
+		Value: value,
+	}
+}
+
+// String 返回 <Key, Value> 形式的字符串，Key 和 Value 都使用 %v 格式化
+func (p Pair[K, V]) String() string {
+	return fmt.Sprintf("<%v, %v>", p.Key, p.Value)
+}
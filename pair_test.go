@@ -0,0 +1,63 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPair(t *testing.T) {
+	p := NewPair("a", 1)
+	assert.Equal(t, Pair[string, int]{Key: "a", Value: 1}, p)
+}
+
+func TestPair_String(t *testing.T) {
+	testCases := []struct {
+		name string
+		pair fmt.Stringer
+		want string
+	}{
+		{
+			name: "zero value",
+			pair: Pair[string, int]{},
+			want: "<, 0>",
+		},
+		{
+			name: "string and int",
+			pair: NewPair("a", 1),
+			want: "<a, 1>",
+		},
+		{
+			name: "pointer and slice",
+			pair: NewPair[*int, []int](nil, []int{1, 2}),
+			want: "<<nil>, [1 2]>",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.pair.String())
+		})
+	}
+}
+
+func ExampleNewPair() {
+	p := NewPair("a", 1)
+	fmt.Println(p)
+	// Output:
+	// <a, 1>
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// 检查ArrayBlockingQueue是否实现了BlockingQueue接口
+var (
+	_ BlockingQueue[any] = &ArrayBlockingQueue[any]{}
+)
+
+// ArrayBlockingQueue 基于环形数组的有界阻塞队列，遵循 FIFO
+// 队列满的时候 Enqueue 会阻塞，队列空的时候 Dequeue 会阻塞
+// 创建之后容量固定，不会扩缩容
+type ArrayBlockingQueue[T any] struct {
+	data  []T
+	head  int // 队首元素的下标
+	tail  int // 下一个入队元素的下标
+	count int // 队列中的元素个数
+
+	mutex         *sync.Mutex
+	dequeueSignal *cond // 出队时发出信号
+	enqueueSignal *cond // 入队时发出信号
+}
+
+// NewArrayBlockingQueue 创建一个容量为 capacity 的有界阻塞队列
+// capacity 必须大于 0，否则会 panic
+func NewArrayBlockingQueue[T any](capacity int) *ArrayBlockingQueue[T] {
+	if capacity <= 0 {
+		panic("queue: ArrayBlockingQueue 的容量必须大于 0")
+	}
+	m := &sync.Mutex{}
+	return &ArrayBlockingQueue[T]{
+		data:          make([]T, capacity),
+		mutex:         m,
+		dequeueSignal: newCond(m),
+		enqueueSignal: newCond(m),
+	}
+}
+
+// Enqueue 入队，队列已满的时候会阻塞，直到有空闲位置或者 ctx 过期
+func (a *ArrayBlockingQueue[T]) Enqueue(ctx context.Context, t T) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		a.mutex.Lock()
+		if a.count < len(a.data) {
+			a.data[a.tail] = t
+			a.tail = (a.tail + 1) % len(a.data)
+			a.count++
+			a.enqueueSignal.broadcast()
+			return nil
+		}
+		// 队列已满，等待出队信号
+		signal := a.dequeueSignal.signalCh()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-signal:
+		}
+	}
+}
+
+// Dequeue 出队，队列为空的时候会阻塞，直到有元素入队或者 ctx 过期
+func (a *ArrayBlockingQueue[T]) Dequeue(ctx context.Context) (T, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			var t T
+			return t, ctx.Err()
+		default:
+		}
+		a.mutex.Lock()
+		if a.count > 0 {
+			val := a.data[a.head]
+			// 清空引用，方便 GC
+			var zero T
+			a.data[a.head] = zero
+			a.head = (a.head + 1) % len(a.data)
+			a.count--
+			a.dequeueSignal.broadcast()
+			return val, nil
+		}
+		// 队列为空，等待入队信号
+		signal := a.enqueueSignal.signalCh()
+		select {
+		case <-ctx.Done():
+			var t T
+			return t, ctx.Err()
+		case <-signal:
+		}
+	}
+}
+
+// Len 返回队列中的元素个数
+func (a *ArrayBlockingQueue[T]) Len() int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.count
+}
+
+// Cap 返回队列的容量
+func (a *ArrayBlockingQueue[T]) Cap() int {
+	return len(a.data)
+}
@@ -0,0 +1,264 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewArrayBlockingQueue(t *testing.T) {
+	assert.Panics(t, func() {
+		NewArrayBlockingQueue[int](0)
+	})
+	q := NewArrayBlockingQueue[int](3)
+	assert.Equal(t, 3, q.Cap())
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestArrayBlockingQueue_Enqueue(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		q        func() *ArrayBlockingQueue[int]
+		timeout  time.Duration
+		val      int
+		wantData []int
+		wantErr  error
+	}{
+		{
+			name: "empty",
+			q: func() *ArrayBlockingQueue[int] {
+				return NewArrayBlockingQueue[int](3)
+			},
+			timeout:  time.Second,
+			val:      123,
+			wantData: []int{123},
+		},
+		{
+			name: "wrap around",
+			q: func() *ArrayBlockingQueue[int] {
+				q := newArrayBlockingQueue(t, 3, 1, 2, 3)
+				_, err := q.Dequeue(context.Background())
+				require.NoError(t, err)
+				return q
+			},
+			timeout:  time.Second,
+			val:      4,
+			wantData: []int{2, 3, 4},
+		},
+		{
+			name: "invalid context",
+			q: func() *ArrayBlockingQueue[int] {
+				return NewArrayBlockingQueue[int](3)
+			},
+			timeout:  -time.Second,
+			val:      123,
+			wantData: []int{},
+			wantErr:  context.DeadlineExceeded,
+		},
+		{
+			name: "full and timeout",
+			q: func() *ArrayBlockingQueue[int] {
+				return newArrayBlockingQueue(t, 2, 1, 2)
+			},
+			timeout:  time.Millisecond * 100,
+			val:      3,
+			wantData: []int{1, 2},
+			wantErr:  context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.q()
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			err := q.Enqueue(ctx, tc.val)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantData, q.asSlice())
+		})
+	}
+
+	// 队列满了，等待一段时间之后有元素出队
+	t.Run("enqueue while dequeue", func(t *testing.T) {
+		q := newArrayBlockingQueue(t, 1, 1)
+		go func() {
+			time.Sleep(time.Millisecond * 100)
+			val, err := q.Dequeue(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 1, val)
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, q.Enqueue(ctx, 2))
+		assert.Equal(t, []int{2}, q.asSlice())
+	})
+}
+
+func TestArrayBlockingQueue_Dequeue(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		q        func() *ArrayBlockingQueue[int]
+		timeout  time.Duration
+		wantVal  int
+		wantData []int
+		wantErr  error
+	}{
+		{
+			name: "multiple",
+			q: func() *ArrayBlockingQueue[int] {
+				return newArrayBlockingQueue(t, 3, 1, 2)
+			},
+			timeout:  time.Second,
+			wantVal:  1,
+			wantData: []int{2},
+		},
+		{
+			name: "wrap around",
+			q: func() *ArrayBlockingQueue[int] {
+				q := newArrayBlockingQueue(t, 2, 1, 2)
+				_, err := q.Dequeue(context.Background())
+				require.NoError(t, err)
+				require.NoError(t, q.Enqueue(context.Background(), 3))
+				_, err = q.Dequeue(context.Background())
+				require.NoError(t, err)
+				return q
+			},
+			timeout:  time.Second,
+			wantVal:  3,
+			wantData: []int{},
+		},
+		{
+			name: "invalid context",
+			q: func() *ArrayBlockingQueue[int] {
+				return newArrayBlockingQueue(t, 3, 1)
+			},
+			timeout: -time.Second,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name: "empty and timeout",
+			q: func() *ArrayBlockingQueue[int] {
+				return NewArrayBlockingQueue[int](3)
+			},
+			timeout: time.Millisecond * 100,
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.q()
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			val, err := q.Dequeue(ctx)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantVal, val)
+			assert.Equal(t, tc.wantData, q.asSlice())
+		})
+	}
+
+	// 队列为空，等待一段时间之后有元素入队
+	t.Run("dequeue while enqueue", func(t *testing.T) {
+		q := NewArrayBlockingQueue[int](1)
+		go func() {
+			time.Sleep(time.Millisecond * 100)
+			assert.NoError(t, q.Enqueue(context.Background(), 123))
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		val, err := q.Dequeue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 123, val)
+	})
+}
+
+func TestArrayBlockingQueue_EnqueueDequeue(t *testing.T) {
+	t.Parallel()
+	// 多个生产者和消费者并发执行，容量远小于元素总数，确保所有元素都被消费
+	q := NewArrayBlockingQueue[int](5)
+	const producers, perProducer = 10, 100
+	var wg sync.WaitGroup
+	for i := 0; i < producers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perProducer; j++ {
+				assert.NoError(t, q.Enqueue(context.Background(), i*perProducer+j))
+			}
+		}(i)
+	}
+	resultChan := make(chan int, producers*perProducer)
+	for i := 0; i < producers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perProducer; j++ {
+				val, err := q.Dequeue(context.Background())
+				assert.NoError(t, err)
+				resultChan <- val
+			}
+		}()
+	}
+	wg.Wait()
+	close(resultChan)
+	resultSet := make(map[int]struct{}, producers*perProducer)
+	for val := range resultChan {
+		resultSet[val] = struct{}{}
+	}
+	assert.Equal(t, producers*perProducer, len(resultSet))
+	assert.Equal(t, 0, q.Len())
+}
+
+func newArrayBlockingQueue(t *testing.T, capacity int, vals ...int) *ArrayBlockingQueue[int] {
+	q := NewArrayBlockingQueue[int](capacity)
+	for _, val := range vals {
+		require.NoError(t, q.Enqueue(context.Background(), val))
+	}
+	return q
+}
+
+func (a *ArrayBlockingQueue[T]) asSlice() []T {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	res := make([]T, 0, a.count)
+	for i := 0; i < a.count; i++ {
+		res = append(res, a.data[(a.head+i)%len(a.data)])
+	}
+	return res
+}
+
+func ExampleNewArrayBlockingQueue() {
+	q := NewArrayBlockingQueue[int](2)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_ = q.Enqueue(ctx, 1)
+	_ = q.Enqueue(ctx, 2)
+	val, _ := q.Dequeue(ctx)
+	fmt.Println(val)
+	// Output:
+	// 1
+}
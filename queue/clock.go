@@ -0,0 +1,59 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import "time"
+
+// Clock 时间源，DelayQueue 通过它来获取当前时间以及创建定时器
+// 默认使用真实的系统时间，可以通过 WithClock 替换成自己实现的 Clock
+type Clock interface {
+	// Now 返回当前时间
+	Now() time.Time
+	// NewTimer 创建一个 d 之后触发的定时器
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer 定时器，语义和 time.Timer 保持一致
+type Timer interface {
+	// C 返回定时器触发时发送当前时间的 channel
+	C() <-chan time.Time
+	// Stop 停止定时器，如果定时器已经触发或者已经停止，返回 false
+	Stop() bool
+	// Reset 让定时器在 d 之后重新触发，返回值的含义和 time.Timer.Reset 一致
+	Reset(d time.Duration) bool
+}
+
+// RealClock 返回基于系统时间的 Clock
+func RealClock() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{Timer: time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (r realTimer) C() <-chan time.Time {
+	return r.Timer.C
+}
@@ -0,0 +1,165 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRealClock(t *testing.T) {
+	t.Parallel()
+	clock := RealClock()
+	before := time.Now()
+	now := clock.Now()
+	assert.False(t, now.Before(before))
+
+	timer := clock.NewTimer(10 * time.Millisecond)
+	select {
+	case <-timer.C():
+	case <-time.After(time.Second):
+		t.Fatal("timer 没有触发")
+	}
+	assert.False(t, timer.Stop())
+
+	timer.Reset(time.Hour)
+	assert.True(t, timer.Stop())
+}
+
+func TestFakeClock(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	timer := clock.NewTimer(time.Second)
+	assert.Equal(t, 1, clock.activeTimers())
+
+	clock.Advance(500 * time.Millisecond)
+	assert.Equal(t, start.Add(500*time.Millisecond), clock.Now())
+	select {
+	case <-timer.C():
+		t.Fatal("timer 不应该触发")
+	default:
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	assert.Equal(t, start.Add(time.Second), <-timer.C())
+	assert.Equal(t, 0, clock.activeTimers())
+	assert.False(t, timer.Stop())
+
+	assert.False(t, timer.Reset(time.Second))
+	assert.True(t, timer.Stop())
+	clock.Advance(time.Second)
+	select {
+	case <-timer.C():
+		t.Fatal("已经停止的 timer 不应该触发")
+	default:
+	}
+}
+
+// fakeClock 只能通过 Advance 推进的时钟，用于测试
+type fakeClock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) NewTimer(d time.Duration) Timer {
+	timer := &fakeTimer{
+		clock: f,
+		c:     make(chan time.Time, 1),
+	}
+	f.mutex.Lock()
+	f.timers = append(f.timers, timer)
+	f.mutex.Unlock()
+	timer.Reset(d)
+	return timer
+}
+
+// Advance 推进时钟，并且触发所有到期的 timer
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.now = f.now.Add(d)
+	for _, timer := range f.timers {
+		timer.fireIfExpired()
+	}
+}
+
+// activeTimers 返回还没有触发也没有停止的 timer 个数
+func (f *fakeClock) activeTimers() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	cnt := 0
+	for _, timer := range f.timers {
+		if timer.active {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+type fakeTimer struct {
+	clock    *fakeClock
+	c        chan time.Time
+	deadline time.Time
+	active   bool
+}
+
+func (f *fakeTimer) C() <-chan time.Time {
+	return f.c
+}
+
+func (f *fakeTimer) Stop() bool {
+	f.clock.mutex.Lock()
+	defer f.clock.mutex.Unlock()
+	active := f.active
+	f.active = false
+	return active
+}
+
+func (f *fakeTimer) Reset(d time.Duration) bool {
+	f.clock.mutex.Lock()
+	defer f.clock.mutex.Unlock()
+	active := f.active
+	f.active = true
+	f.deadline = f.clock.now.Add(d)
+	f.fireIfExpired()
+	return active
+}
+
+// fireIfExpired 必须持有 clock 的锁
+func (f *fakeTimer) fireIfExpired() {
+	if !f.active || f.deadline.After(f.clock.now) {
+		return
+	}
+	f.active = false
+	select {
+	case f.c <- f.clock.now:
+	default:
+	}
+}
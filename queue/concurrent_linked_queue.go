@@ -15,18 +15,33 @@
 package queue
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"sync/atomic"
 	"unsafe"
 
 	"github.com/go-generic/internal/queue"
 )
 
-// ConcurrentLinkedQueue 并发安全的无界队列
+// ConcurrentLinkedQueue 并发安全的队列，基于链表实现，默认是无界队列
+// 使用 NewBoundedConcurrentLinkedQueue 创建的是有界队列
 type ConcurrentLinkedQueue[T any] struct {
 	// *node[T]
 	head unsafe.Pointer
 	// *node[T]
 	tail unsafe.Pointer
+	// 队列中的元素个数
+	// 入队的时候先占位再链接节点，出队的时候先摘下节点再减少，所以它不会小于实际的元素个数
+	size atomic.Int64
+	// 容量，小于等于 0 时为无界队列
+	capacity int64
+
+	// 以下字段只用于 DequeueBlocking
+	// 只有存在等待者的时候，Enqueue 才会加锁发出信号，否则入队依旧是无锁的
+	mutex         *sync.Mutex
+	enqueueSignal *cond // 入队时发出信号
+	waiters       atomic.Int32
 }
 
 // NewConcurrentLinkedQueue 创建一个新的并发安全的无界队列
@@ -34,14 +49,36 @@ func NewConcurrentLinkedQueue[T any]() *ConcurrentLinkedQueue[T] {
 	// 创建一个空node，头指针 尾指针都指向这个地址
 	head := &node[T]{}
 	ptr := unsafe.Pointer(head)
+	m := &sync.Mutex{}
 	return &ConcurrentLinkedQueue[T]{
-		head: ptr,
-		tail: ptr,
+		head:          ptr,
+		tail:          ptr,
+		mutex:         m,
+		enqueueSignal: newCond(m),
 	}
 }
 
-// Enqueue 并发安全无界队列入队
+// NewBoundedConcurrentLinkedQueue 创建一个新的并发安全的有界队列
+// 队列中的元素个数达到 capacity 之后，Enqueue 会返回 ErrOutOfCapacity
+// capacity <= 0 时，等价于 NewConcurrentLinkedQueue
+func NewBoundedConcurrentLinkedQueue[T any](capacity int) *ConcurrentLinkedQueue[T] {
+	res := NewConcurrentLinkedQueue[T]()
+	res.capacity = int64(capacity)
+	return res
+}
+
+// Enqueue 并发安全队列入队
+// 有界队列已满的时候，返回 ErrOutOfCapacity
 func (c *ConcurrentLinkedQueue[T]) Enqueue(t T) error {
+	if err := c.reserve(); err != nil {
+		return err
+	}
+	c.link(t)
+	return nil
+}
+
+// link 将元素链接到队尾，调用者需要先通过 reserve 占好位置
+func (c *ConcurrentLinkedQueue[T]) link(t T) {
 	// 创建入队节点，并获取节点指针ptr
 	newNode := &node[T]{val: t}
 	newPtr := unsafe.Pointer(newNode)
@@ -62,12 +99,31 @@ func (c *ConcurrentLinkedQueue[T]) Enqueue(t T) error {
 			// 如果失败也不用担心，说明有人抢先一步了
 			// 添加成功，更新队列的tail指针
 			atomic.CompareAndSwapPointer(&c.tail, tailPtr, newPtr)
+			c.notifyWaiters()
+			return
+		}
+	}
+}
+
+// reserve 为入队的元素占一个位置
+// 有界队列通过 CAS 来保证元素个数不会超过容量，整个过程不需要加锁
+func (c *ConcurrentLinkedQueue[T]) reserve() error {
+	if c.capacity <= 0 {
+		c.size.Add(1)
+		return nil
+	}
+	for {
+		size := c.size.Load()
+		if size >= c.capacity {
+			return queue.ErrOutOfCapacity
+		}
+		if c.size.CompareAndSwap(size, size+1) {
 			return nil
 		}
 	}
 }
 
-// Dequeue 并发安全无界队列出队
+// Dequeue 并发安全队列出队
 func (c *ConcurrentLinkedQueue[T]) Dequeue() (T, error) {
 	for {
 		// 获取队列头节点
@@ -89,11 +145,190 @@ func (c *ConcurrentLinkedQueue[T]) Dequeue() (T, error) {
 		if atomic.CompareAndSwapPointer(&c.head, headPtr, headNextPtr) {
 			// 返回队首节点（head 指针指向队列的头节点,但实际上队列的第一个元素是 head.next 指向的节点）
 			headNext := (*node[T])(headNextPtr)
+			c.size.Add(-1)
 			return headNext.val, nil
 		}
 	}
 }
 
+// DequeueBlocking 出队，队列为空的时候会阻塞，直到有元素入队或者 ctx 过期
+// 如果不希望阻塞，请使用 Dequeue
+func (c *ConcurrentLinkedQueue[T]) DequeueBlocking(ctx context.Context) (T, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			var t T
+			return t, ctx.Err()
+		default:
+		}
+		val, err := c.Dequeue()
+		if err == nil {
+			return val, nil
+		}
+		c.mutex.Lock()
+		// 先登记为等待者再重试一次，
+		// 这样在重试之后才完成的入队必然能看到等待者，从而发出信号，不会丢失唤醒
+		c.waiters.Add(1)
+		val, err = c.Dequeue()
+		if err == nil {
+			c.waiters.Add(-1)
+			c.mutex.Unlock()
+			return val, nil
+		}
+		signal := c.enqueueSignal.signalCh()
+		select {
+		case <-ctx.Done():
+			c.waiters.Add(-1)
+			var t T
+			return t, ctx.Err()
+		case <-signal:
+			c.waiters.Add(-1)
+		}
+	}
+}
+
+// notifyWaiters 唤醒阻塞在 DequeueBlocking 上的协程
+func (c *ConcurrentLinkedQueue[T]) notifyWaiters() {
+	if c.waiters.Load() == 0 {
+		return
+	}
+	c.mutex.Lock()
+	c.enqueueSignal.broadcast()
+}
+
+// Peek 返回队首元素，但是不会将其出队
+// 如果队列为空，返回 ErrEmptyQueue
+func (c *ConcurrentLinkedQueue[T]) Peek() (T, error) {
+	for {
+		headPtr := atomic.LoadPointer(&c.head)
+		head := (*node[T])(headPtr)
+		tailPtr := atomic.LoadPointer(&c.tail)
+		tail := (*node[T])(tailPtr)
+		if head == tail {
+			var t T
+			return t, queue.ErrEmptyQueue
+		}
+		headNextPtr := atomic.LoadPointer(&head.next)
+		// 读取 next 的过程中，head 可能已经被其它协程出队了，此时 headNext 已经不是队首元素，需要重试
+		if atomic.LoadPointer(&c.head) == headPtr {
+			headNext := (*node[T])(headNextPtr)
+			return headNext.val, nil
+		}
+	}
+}
+
+// DequeueN 批量出队，最多出队 n 个元素，按照 FIFO 的顺序返回
+// 队列中的元素不足 n 个的时候，返回所有的元素；队列为空或者 n <= 0 的时候返回一个空切片
+// 每个元素依旧是单独出队的，所以在并发的情况下，返回的元素不一定是连续的
+func (c *ConcurrentLinkedQueue[T]) DequeueN(n int) []T {
+	res := make([]T, 0, max(min(int64(n), c.Len()), 0))
+	for len(res) < n {
+		val, err := c.Dequeue()
+		if err != nil {
+			break
+		}
+		res = append(res, val)
+	}
+	return res
+}
+
+// Drain 将队列中的元素全部出队，按照 FIFO 的顺序返回
+// 只保证取出调用时已经在队列中的元素，并发入队的元素可能被取出，也可能不会
+// 队列为空的时候返回一个空切片而不是 nil
+func (c *ConcurrentLinkedQueue[T]) Drain() []T {
+	res := make([]T, 0, c.Len())
+	for {
+		val, err := c.Dequeue()
+		if err != nil {
+			return res
+		}
+		res = append(res, val)
+	}
+}
+
+// Reset 清空队列，之后队列可以继续使用，被清空的节点会被 GC 回收
+// 实现上是将头指针一次性移动到尾节点，尾节点成为新的哨兵节点，整个过程是无锁的，
+// 所以可以和 Enqueue、Dequeue 并发调用而不会破坏队列的结构，Len 也会相应地减少
+// 和 Drain 一样，只保证清空调用时已经在队列中的元素，并发入队的元素可能被清空，也可能不会
+func (c *ConcurrentLinkedQueue[T]) Reset() {
+	for {
+		headPtr := atomic.LoadPointer(&c.head)
+		tailPtr := atomic.LoadPointer(&c.tail)
+		if headPtr == tailPtr {
+			return
+		}
+		// 头指针不会越过尾指针，所以从 head 出发必然能够走到 tail
+		var cnt int64
+		for cur := headPtr; cur != tailPtr; cur = atomic.LoadPointer(&(*node[T])(cur).next) {
+			cnt++
+		}
+		if atomic.CompareAndSwapPointer(&c.head, headPtr, tailPtr) {
+			c.size.Add(-cnt)
+			return
+		}
+	}
+}
+
+// DrainTo 将队列中的元素按照 FIFO 的顺序转移到 dst 中，返回转移的元素个数
+// 只保证转移调用时已经在队列中的元素，并发入队的元素可能被转移，也可能不会
+// 如果 dst 是有界队列，那么 dst 满了之后就会停止转移，剩余的元素依旧留在当前队列中，不会丢失
+// dst 和当前队列是同一个队列的时候，什么也不做，返回 0
+func (c *ConcurrentLinkedQueue[T]) DrainTo(dst *ConcurrentLinkedQueue[T]) int {
+	if dst == c {
+		return 0
+	}
+	n := c.Len()
+	cnt := 0
+	for int64(cnt) < n {
+		// 先在 dst 中占好位置再出队，这样 dst 满了的时候元素不会被取出来
+		if dst.reserve() != nil {
+			break
+		}
+		val, err := c.Dequeue()
+		if err != nil {
+			// 归还占用的位置
+			dst.size.Add(-1)
+			break
+		}
+		dst.link(val)
+		cnt++
+	}
+	return cnt
+}
+
+// IsEmpty 判断队列是否为空，不会修改队列，也不需要加锁
+// 和 Dequeue 使用同样的判断方式：头指针和尾指针指向同一个节点的时候认为队列为空
+// 在并发入队出队的情况下，返回值只是调用那一刻的快照
+func (c *ConcurrentLinkedQueue[T]) IsEmpty() bool {
+	headPtr := atomic.LoadPointer(&c.head)
+	tailPtr := atomic.LoadPointer(&c.tail)
+	return headPtr == tailPtr
+}
+
+// String 按照 FIFO 的顺序输出队列中的所有元素，格式和 fmt.Sprint 输出切片一致，例如 [1 2 3]
+// 遍历的过程中不会加锁，在并发入队出队的情况下，输出只是一个尽力而为的快照
+func (c *ConcurrentLinkedQueue[T]) String() string {
+	var vals []T
+	head := (*node[T])(atomic.LoadPointer(&c.head))
+	// head 是哨兵节点，第一个元素是 head.next
+	for cur := (*node[T])(atomic.LoadPointer(&head.next)); cur != nil; cur = (*node[T])(atomic.LoadPointer(&cur.next)) {
+		vals = append(vals, cur.val)
+	}
+	return fmt.Sprint(vals)
+}
+
+// Cap 返回队列的容量，无界队列返回 0
+func (c *ConcurrentLinkedQueue[T]) Cap() int {
+	return int(max(c.capacity, 0))
+}
+
+// Len 返回队列中的元素个数
+// 在并发入队出队的情况下，返回值只是一个近似值，可能会包含正在入队的元素；
+// 当没有并发操作的时候，返回值就是准确的元素个数
+func (c *ConcurrentLinkedQueue[T]) Len() int64 {
+	return c.size.Load()
+}
+
 type node[T any] struct {
 	val T
 	// *node[T]
@@ -15,11 +15,13 @@
 package queue
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -54,6 +56,28 @@ func TestConcurrentQueue_Enqueue(t *testing.T) {
 			val:      234,
 			wantData: []int{123, 234},
 		},
+		{
+			name: "bounded",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewBoundedConcurrentLinkedQueue[int](2)
+				require.NoError(t, q.Enqueue(123))
+				return q
+			},
+			val:      234,
+			wantData: []int{123, 234},
+		},
+		{
+			name: "bounded and full",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewBoundedConcurrentLinkedQueue[int](2)
+				require.NoError(t, q.Enqueue(123))
+				require.NoError(t, q.Enqueue(234))
+				return q
+			},
+			val:      345,
+			wantData: []int{123, 234},
+			wantErr:  errOutOfCapacity,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -62,6 +86,7 @@ func TestConcurrentQueue_Enqueue(t *testing.T) {
 			err := q.Enqueue(tc.val)
 			assert.Equal(t, tc.wantErr, err)
 			assert.Equal(t, tc.wantData, q.asSlice())
+			assert.Equal(t, int64(len(tc.wantData)), q.Len())
 		})
 	}
 }
@@ -136,8 +161,551 @@ func TestConcurrentQueue_Dequeue(t *testing.T) {
 			}
 			assert.Equal(t, tc.wantVal, val)
 			assert.Equal(t, tc.wantData, q.asSlice())
+			assert.Equal(t, int64(len(tc.wantData)), q.Len())
+		})
+	}
+}
+
+func TestConcurrentLinkedQueue_DequeueBlocking(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		q        func() *ConcurrentLinkedQueue[int]
+		timeout  time.Duration
+		wantVal  int
+		wantData []int
+		wantErr  error
+	}{
+		{
+			name: "multiple",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewConcurrentLinkedQueue[int]()
+				require.NoError(t, q.Enqueue(123))
+				require.NoError(t, q.Enqueue(234))
+				return q
+			},
+			timeout:  time.Second,
+			wantVal:  123,
+			wantData: []int{234},
+		},
+		{
+			name: "invalid context",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewConcurrentLinkedQueue[int]()
+				require.NoError(t, q.Enqueue(123))
+				return q
+			},
+			timeout:  -time.Second,
+			wantErr:  context.DeadlineExceeded,
+			wantData: []int{123},
+		},
+		{
+			name: "empty and timeout",
+			q: func() *ConcurrentLinkedQueue[int] {
+				return NewConcurrentLinkedQueue[int]()
+			},
+			timeout: time.Millisecond * 100,
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.q()
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			val, err := q.DequeueBlocking(ctx)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantData, q.asSlice())
+			assert.Equal(t, int32(0), q.waiters.Load())
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantVal, val)
+		})
+	}
+
+	// 队列为空，等待一段时间之后有元素入队
+	t.Run("dequeue while enqueue", func(t *testing.T) {
+		q := NewConcurrentLinkedQueue[int]()
+		go func() {
+			time.Sleep(time.Millisecond * 100)
+			assert.NoError(t, q.Enqueue(123))
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		val, err := q.DequeueBlocking(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 123, val)
+	})
+
+	// 多个消费者阻塞等待，所有入队的元素都能被消费
+	t.Run("multiple consumers", func(t *testing.T) {
+		q := NewConcurrentLinkedQueue[int]()
+		const consumers, perConsumer = 10, 100
+		resultChan := make(chan int, consumers*perConsumer)
+		var wg sync.WaitGroup
+		for i := 0; i < consumers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+				defer cancel()
+				for j := 0; j < perConsumer; j++ {
+					val, err := q.DequeueBlocking(ctx)
+					if !assert.NoError(t, err) {
+						return
+					}
+					resultChan <- val
+				}
+			}()
+		}
+		for i := 0; i < consumers*perConsumer; i++ {
+			require.NoError(t, q.Enqueue(i))
+		}
+		wg.Wait()
+		close(resultChan)
+		resultSet := make(map[int]struct{}, consumers*perConsumer)
+		for val := range resultChan {
+			resultSet[val] = struct{}{}
+		}
+		assert.Equal(t, consumers*perConsumer, len(resultSet))
+	})
+}
+
+func TestConcurrentLinkedQueue_Peek(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		q        func() *ConcurrentLinkedQueue[int]
+		wantVal  int
+		wantData []int
+		wantErr  error
+	}{
+		{
+			name: "empty",
+			q: func() *ConcurrentLinkedQueue[int] {
+				return NewConcurrentLinkedQueue[int]()
+			},
+			wantErr: errEmptyQueue,
+		},
+		{
+			name: "multiple",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewConcurrentLinkedQueue[int]()
+				assert.NoError(t, q.Enqueue(123))
+				assert.NoError(t, q.Enqueue(234))
+				return q
+			},
+			wantVal:  123,
+			wantData: []int{123, 234},
+		},
+		{
+			name: "after dequeue",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewConcurrentLinkedQueue[int]()
+				assert.NoError(t, q.Enqueue(123))
+				assert.NoError(t, q.Enqueue(234))
+				_, err := q.Dequeue()
+				assert.NoError(t, err)
+				return q
+			},
+			wantVal:  234,
+			wantData: []int{234},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.q()
+			val, err := q.Peek()
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantVal, val)
+			// Peek 不会修改队列
+			assert.Equal(t, tc.wantData, q.asSlice())
+		})
+	}
+}
+
+func TestConcurrentLinkedQueue_DequeueN(t *testing.T) {
+	t.Parallel()
+	newQueue := func() *ConcurrentLinkedQueue[int] {
+		q := NewConcurrentLinkedQueue[int]()
+		assert.NoError(t, q.Enqueue(123))
+		assert.NoError(t, q.Enqueue(234))
+		assert.NoError(t, q.Enqueue(345))
+		return q
+	}
+	testCases := []struct {
+		name     string
+		q        *ConcurrentLinkedQueue[int]
+		n        int
+		want     []int
+		wantData []int
+	}{
+		{
+			name: "empty",
+			q:    NewConcurrentLinkedQueue[int](),
+			n:    2,
+			want: []int{},
+		},
+		{
+			name:     "n is zero",
+			q:        newQueue(),
+			n:        0,
+			want:     []int{},
+			wantData: []int{123, 234, 345},
+		},
+		{
+			name:     "n is negative",
+			q:        newQueue(),
+			n:        -1,
+			want:     []int{},
+			wantData: []int{123, 234, 345},
+		},
+		{
+			name:     "less than len",
+			q:        newQueue(),
+			n:        2,
+			want:     []int{123, 234},
+			wantData: []int{345},
+		},
+		{
+			name: "more than len",
+			q:    newQueue(),
+			n:    5,
+			want: []int{123, 234, 345},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.q.DequeueN(tc.n))
+			assert.Equal(t, tc.wantData, tc.q.asSlice())
+			assert.Equal(t, int64(len(tc.wantData)), tc.q.Len())
+		})
+	}
+}
+
+func TestConcurrentLinkedQueue_Drain(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name string
+		q    func() *ConcurrentLinkedQueue[int]
+		want []int
+	}{
+		{
+			name: "empty",
+			q: func() *ConcurrentLinkedQueue[int] {
+				return NewConcurrentLinkedQueue[int]()
+			},
+			want: []int{},
+		},
+		{
+			name: "multiple",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewConcurrentLinkedQueue[int]()
+				assert.NoError(t, q.Enqueue(123))
+				assert.NoError(t, q.Enqueue(234))
+				assert.NoError(t, q.Enqueue(345))
+				return q
+			},
+			want: []int{123, 234, 345},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.q()
+			assert.Equal(t, tc.want, q.Drain())
+			assert.Equal(t, int64(0), q.Len())
+			_, err := q.Dequeue()
+			assert.Equal(t, errEmptyQueue, err)
+		})
+	}
+}
+
+func TestConcurrentLinkedQueue_DrainTo(t *testing.T) {
+	t.Parallel()
+	newQueue := func(vals ...int) *ConcurrentLinkedQueue[int] {
+		q := NewConcurrentLinkedQueue[int]()
+		for _, val := range vals {
+			assert.NoError(t, q.Enqueue(val))
+		}
+		return q
+	}
+	newBoundedQueue := func(capacity int, vals ...int) *ConcurrentLinkedQueue[int] {
+		q := NewBoundedConcurrentLinkedQueue[int](capacity)
+		for _, val := range vals {
+			assert.NoError(t, q.Enqueue(val))
+		}
+		return q
+	}
+	testCases := []struct {
+		name        string
+		src         *ConcurrentLinkedQueue[int]
+		dst         *ConcurrentLinkedQueue[int]
+		wantCnt     int
+		wantSrcData []int
+		wantDstData []int
+	}{
+		{
+			name: "empty",
+			src:  newQueue(),
+			dst:  newQueue(),
+		},
+		{
+			name:        "to empty",
+			src:         newQueue(123, 234, 345),
+			dst:         newQueue(),
+			wantCnt:     3,
+			wantDstData: []int{123, 234, 345},
+		},
+		{
+			name:        "append to dst",
+			src:         newQueue(234, 345),
+			dst:         newQueue(123),
+			wantCnt:     2,
+			wantDstData: []int{123, 234, 345},
+		},
+		{
+			name:        "dst bounded",
+			src:         newQueue(234, 345, 456),
+			dst:         newBoundedQueue(3, 123),
+			wantCnt:     2,
+			wantSrcData: []int{456},
+			wantDstData: []int{123, 234, 345},
+		},
+		{
+			name:        "dst full",
+			src:         newQueue(234, 345),
+			dst:         newBoundedQueue(1, 123),
+			wantSrcData: []int{234, 345},
+			wantDstData: []int{123},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantCnt, tc.src.DrainTo(tc.dst))
+			assert.Equal(t, tc.wantSrcData, tc.src.asSlice())
+			assert.Equal(t, tc.wantDstData, tc.dst.asSlice())
+			assert.Equal(t, int64(len(tc.wantSrcData)), tc.src.Len())
+			assert.Equal(t, int64(len(tc.wantDstData)), tc.dst.Len())
 		})
 	}
+
+	t.Run("self", func(t *testing.T) {
+		q := newQueue(123, 234)
+		assert.Equal(t, 0, q.DrainTo(q))
+		assert.Equal(t, []int{123, 234}, q.asSlice())
+	})
+
+	t.Run("wake up dst waiter", func(t *testing.T) {
+		src := newQueue(123)
+		dst := newQueue()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			src.DrainTo(dst)
+		}()
+		val, err := dst.DequeueBlocking(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 123, val)
+	})
+}
+
+func TestConcurrentLinkedQueue_IsEmpty(t *testing.T) {
+	t.Parallel()
+	q := NewConcurrentLinkedQueue[int]()
+	assert.True(t, q.IsEmpty())
+	assert.NoError(t, q.Enqueue(123))
+	assert.False(t, q.IsEmpty())
+	assert.NoError(t, q.Enqueue(234))
+	assert.False(t, q.IsEmpty())
+	_, err := q.Dequeue()
+	assert.NoError(t, err)
+	assert.False(t, q.IsEmpty())
+	_, err = q.Dequeue()
+	assert.NoError(t, err)
+	assert.True(t, q.IsEmpty())
+}
+
+func TestConcurrentLinkedQueue_String(t *testing.T) {
+	t.Parallel()
+	q := NewConcurrentLinkedQueue[int]()
+	assert.Equal(t, "[]", q.String())
+	assert.NoError(t, q.Enqueue(123))
+	assert.NoError(t, q.Enqueue(234))
+	assert.NoError(t, q.Enqueue(345))
+	assert.Equal(t, "[123 234 345]", q.String())
+	_, err := q.Dequeue()
+	assert.NoError(t, err)
+	// 已经出队的元素不会出现在输出中
+	assert.Equal(t, "[234 345]", fmt.Sprint(q))
+}
+
+func TestConcurrentLinkedQueue_Reset(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name string
+		q    func() *ConcurrentLinkedQueue[int]
+	}{
+		{
+			name: "empty",
+			q: func() *ConcurrentLinkedQueue[int] {
+				return NewConcurrentLinkedQueue[int]()
+			},
+		},
+		{
+			name: "multiple",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewConcurrentLinkedQueue[int]()
+				assert.NoError(t, q.Enqueue(123))
+				assert.NoError(t, q.Enqueue(234))
+				assert.NoError(t, q.Enqueue(345))
+				return q
+			},
+		},
+		{
+			name: "bounded full",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewBoundedConcurrentLinkedQueue[int](2)
+				assert.NoError(t, q.Enqueue(123))
+				assert.NoError(t, q.Enqueue(234))
+				return q
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.q()
+			q.Reset()
+			assert.True(t, q.IsEmpty())
+			assert.Equal(t, int64(0), q.Len())
+			assert.Nil(t, q.asSlice())
+			_, err := q.Dequeue()
+			assert.Equal(t, errEmptyQueue, err)
+
+			// 清空之后可以继续使用
+			assert.NoError(t, q.Enqueue(456))
+			assert.NoError(t, q.Enqueue(567))
+			assert.Equal(t, []int{456, 567}, q.asSlice())
+			assert.Equal(t, int64(2), q.Len())
+			val, err := q.Dequeue()
+			assert.NoError(t, err)
+			assert.Equal(t, 456, val)
+		})
+	}
+
+	t.Run("concurrent", func(t *testing.T) {
+		q := NewConcurrentLinkedQueue[int]()
+		var wg sync.WaitGroup
+		var dequeued atomic.Int64
+		for i := 0; i < 10; i++ {
+			wg.Add(3)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					_ = q.Enqueue(j)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					if _, err := q.Dequeue(); err == nil {
+						dequeued.Add(1)
+					}
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					q.Reset()
+				}
+			}()
+		}
+		wg.Wait()
+		// 结构没有被破坏，元素个数和实际遍历的结果一致
+		assert.Equal(t, int64(len(q.asSlice())), q.Len())
+		assert.GreaterOrEqual(t, q.Len(), int64(0))
+		q.Reset()
+		assert.Equal(t, int64(0), q.Len())
+		assert.True(t, q.IsEmpty())
+	})
+}
+
+func TestConcurrentLinkedQueue_Len(t *testing.T) {
+	t.Parallel()
+	q := NewConcurrentLinkedQueue[int]()
+	assert.Equal(t, int64(0), q.Len())
+
+	// 并发入队完成之后，长度应该是准确的
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = q.Enqueue(j)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(1000), q.Len())
+
+	// 并发出队完成之后，长度应该是准确的
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 60; j++ {
+				_, _ = q.Dequeue()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(400), q.Len())
+}
+
+func TestNewBoundedConcurrentLinkedQueue(t *testing.T) {
+	t.Parallel()
+	q := NewBoundedConcurrentLinkedQueue[int](0)
+	assert.Equal(t, 0, q.Cap())
+	q = NewBoundedConcurrentLinkedQueue[int](500)
+	assert.Equal(t, 500, q.Cap())
+
+	// 并发入队，成功入队的元素个数不会超过容量
+	var wg sync.WaitGroup
+	var success atomic.Int64
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				err := q.Enqueue(j)
+				if err == nil {
+					success.Add(1)
+					continue
+				}
+				assert.Equal(t, errOutOfCapacity, err)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(500), success.Load())
+	assert.Equal(t, int64(500), q.Len())
+	assert.Equal(t, 500, len(q.asSlice()))
+
+	// 出队之后可以继续入队
+	_, err := q.Dequeue()
+	require.NoError(t, err)
+	require.NoError(t, q.Enqueue(1))
+	assert.Equal(t, errOutOfCapacity, q.Enqueue(2))
 }
 
 func TestConcurrentLinkedQueue(t *testing.T) {
@@ -15,16 +15,29 @@
 package queue
 
 import (
+	"context"
 	"sync"
 
 	"github.com/go-generic"
 	"github.com/go-generic/internal/queue"
 )
 
+// 检查ConcurrentPriorityQueue是否实现了Queue接口
+var (
+	_ Queue[any] = &ConcurrentPriorityQueue[any]{}
+)
+
 // ConcurrentPriorityQueue 并发优先队列
+// 读操作（Len、Cap、Peek）使用读锁，写操作（Enqueue、Dequeue）使用写锁
+// 如果希望在队列为空的时候阻塞等待，请使用 DequeueBlocking
 type ConcurrentPriorityQueue[T any] struct {
 	pq queue.PriorityQueue[T]
 	m  sync.RWMutex
+
+	// 以下字段只用于 DequeueBlocking，都必须在写锁范围内访问
+	// 只有存在等待者的时候，Enqueue 才会发出信号，避免每次入队都创建新的 channel
+	enqueueSignal *cond // 入队时发出信号
+	waiters       int
 }
 
 // Len 队列长度
@@ -49,10 +62,21 @@ func (c *ConcurrentPriorityQueue[T]) Peek() (T, error) {
 }
 
 // Enqueue 入队
+// 入队成功之后会唤醒阻塞在 DequeueBlocking 上的协程
 func (c *ConcurrentPriorityQueue[T]) Enqueue(t T) error {
 	c.m.Lock()
-	defer c.m.Unlock()
-	return c.pq.Enqueue(t)
+	if err := c.pq.Enqueue(t); err != nil {
+		c.m.Unlock()
+		return err
+	}
+	if c.waiters == 0 {
+		c.m.Unlock()
+		return nil
+	}
+	// 所有的等待者都会被唤醒，它们会重新竞争出队
+	c.waiters = 0
+	c.enqueueSignal.broadcast()
+	return nil
 }
 
 // Dequeue 出队
@@ -62,9 +86,48 @@ func (c *ConcurrentPriorityQueue[T]) Dequeue() (T, error) {
 	return c.pq.Dequeue()
 }
 
+// DequeueBlocking 出队，队列为空的时候会阻塞，直到有元素入队或者 ctx 过期
+// 和 DelayQueue 的 Dequeue 类似，但是没有延时的语义，只要队列中有元素就会立刻返回优先级最高的元素
+// 多个协程同时等待的时候，入队会唤醒所有的等待者，但是只有抢到元素的协程会返回，其余的会继续等待
+func (c *ConcurrentPriorityQueue[T]) DequeueBlocking(ctx context.Context) (T, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			var t T
+			return t, ctx.Err()
+		default:
+		}
+		c.m.Lock()
+		val, err := c.pq.Dequeue()
+		if err == nil {
+			c.m.Unlock()
+			return val, nil
+		}
+		c.waiters++
+		// signalCh 会释放锁
+		signal := c.enqueueSignal.signalCh()
+		select {
+		case <-ctx.Done():
+			c.m.Lock()
+			select {
+			case <-signal:
+				// 已经被唤醒过了，Enqueue 已经重置了 waiters
+			default:
+				c.waiters--
+			}
+			c.m.Unlock()
+			var t T
+			return t, ctx.Err()
+		case <-signal:
+		}
+	}
+}
+
 // NewConcurrentPriorityQueue 创建优先队列 capacity <= 0 时，为无界队列
 func NewConcurrentPriorityQueue[T any](capacity int, compare generic.Comparator[T]) *ConcurrentPriorityQueue[T] {
-	return &ConcurrentPriorityQueue[T]{
+	res := &ConcurrentPriorityQueue[T]{
 		pq: *queue.NewPriorityQueue[T](capacity, compare),
 	}
+	res.enqueueSignal = newCond(&res.m)
+	return res
 }
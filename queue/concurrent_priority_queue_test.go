@@ -15,10 +15,12 @@
 package queue
 
 import (
+	"context"
 	"fmt"
 	"github.com/go-generic"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-generic/internal/queue"
 	"github.com/stretchr/testify/assert"
@@ -293,6 +295,85 @@ func TestConcurrentPriorityQueue_EnqueueDequeue(t *testing.T) {
 	}
 }
 
+func TestConcurrentPriorityQueue_DequeueBlocking(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not empty", func(t *testing.T) {
+		q := NewConcurrentPriorityQueue[int](0, generic.ComparatorRealNumber[int])
+		require.NoError(t, q.Enqueue(3))
+		require.NoError(t, q.Enqueue(1))
+		val, err := q.DequeueBlocking(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, val)
+	})
+
+	t.Run("invalid context", func(t *testing.T) {
+		q := NewConcurrentPriorityQueue[int](0, generic.ComparatorRealNumber[int])
+		require.NoError(t, q.Enqueue(1))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := q.DequeueBlocking(ctx)
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, 1, q.Len())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		q := NewConcurrentPriorityQueue[int](0, generic.ComparatorRealNumber[int])
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := q.DequeueBlocking(ctx)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		q.m.Lock()
+		assert.Equal(t, 0, q.waiters)
+		q.m.Unlock()
+	})
+
+	t.Run("wait for enqueue", func(t *testing.T) {
+		q := NewConcurrentPriorityQueue[int](0, generic.ComparatorRealNumber[int])
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			_ = q.Enqueue(2)
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		val, err := q.DequeueBlocking(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, val)
+	})
+
+	t.Run("multiple waiters", func(t *testing.T) {
+		q := NewConcurrentPriorityQueue[int](0, generic.ComparatorRealNumber[int])
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		const n = 10
+		var wg sync.WaitGroup
+		res := make(chan int, n)
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				val, err := q.DequeueBlocking(ctx)
+				if err == nil {
+					res <- val
+				}
+			}()
+		}
+		for i := 0; i < n; i++ {
+			time.Sleep(time.Millisecond)
+			require.NoError(t, q.Enqueue(i))
+		}
+		wg.Wait()
+		close(res)
+		vals := make([]int, 0, n)
+		for val := range res {
+			vals = append(vals, val)
+		}
+		// 每个元素都被恰好一个等待者拿到
+		assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, vals)
+		assert.Equal(t, 0, q.Len())
+	})
+}
+
 func ExampleNewConcurrentPriorityQueue() {
 	q := NewConcurrentPriorityQueue[int](10, generic.ComparatorRealNumber[int])
 	_ = q.Enqueue(3)
@@ -16,6 +16,7 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -28,38 +29,97 @@ import (
 // 延时队列本身对时间的精确度并不是很高，其时间精确度主要取决于 time.Timer
 // 所以如果你需要极度精确的延时队列，那么这个结构并不太适合你。
 // 但是如果你能够容忍至多在毫秒级的误差，那么这个结构还是可以使用的
+//
+// 如果元素实现了 Deadliner，那么延时队列会根据 WithClock 注入的 Clock 计算元素的延时，而不是调用 Delay()
 type DelayQueue[T Delayable] struct {
 	q             queue.PriorityQueue[T] // 基于小顶堆的优先队列
 	mutex         *sync.Mutex
 	dequeueSignal *cond // 出队时发出信号
 	enqueueSignal *cond // 入队时发出信号
+	observer      Observer[T]
+	clock         Clock
+	// 只有 NewDelayQueueDedup 创建的队列才会设置，用于合并 key 相同的元素
+	// 所有入队和删除元素的路径都必须经过 enqueue、dequeue 和 remove，以保证索引和堆保持一致
+	index keyIndex[T]
+}
+
+// Observer 用于观察延时队列的入队和出队，例如统计指标
+// 回调是在释放锁之后调用的，所以回调阻塞不会阻塞队列，但是回调的顺序和入队出队的顺序不一定完全一致
+// 回调可能会被多个 goroutine 同时调用，实现者需要保证并发安全
+type Observer[T any] interface {
+	// OnEnqueue 元素入队成功之后调用
+	OnEnqueue(t T)
+	// OnDequeue 元素出队之后调用，lateness 是元素出队的时候已经超过到期时间多久，即出队时 Delay() 的相反数
+	// 通过 Remove 删除的元素和 DrainAll 取出的元素不会触发这个回调
+	OnDequeue(t T, lateness time.Duration)
+}
+
+// DelayQueueOption 延时队列的可选配置
+type DelayQueueOption[T Delayable] func(d *DelayQueue[T])
+
+// WithObserver 设置延时队列的观察者
+func WithObserver[T Delayable](observer Observer[T]) DelayQueueOption[T] {
+	return func(d *DelayQueue[T]) {
+		d.observer = observer
+	}
 }
 
-func NewDelayQueue[T Delayable](c int) *DelayQueue[T] {
+// WithClock 设置延时队列使用的时钟，默认是 RealClock()
+// 只有实现了 Deadliner 的元素才会根据这个时钟计算延时和等待到期，否则依旧使用元素自己的 Delay() 和真实的 timer
+func WithClock[T Delayable](clock Clock) DelayQueueOption[T] {
+	return func(d *DelayQueue[T]) {
+		d.clock = clock
+	}
+}
+
+func NewDelayQueue[T Delayable](c int, opts ...DelayQueueOption[T]) *DelayQueue[T] {
 	m := &sync.Mutex{}
 	res := &DelayQueue[T]{
-		// 根据延时时间
-		q: *queue.NewPriorityQueue[T](c, func(src T, dst T) int {
-			// src 来源  dst 目标
-			srcDelay := src.Delay()
-			dstDelay := dst.Delay()
-			// 来源delay>目标delay return>0
-			if srcDelay > dstDelay {
-				return 1
-			}
-			if srcDelay == dstDelay {
-				return 0
-			}
-			// 来源delay<目标delay return<0
-			return -1
-		}),
 		mutex:         m,
 		dequeueSignal: newCond(m),
 		enqueueSignal: newCond(m),
+		clock:         RealClock(),
+	}
+	// 根据延时时间
+	res.q = *queue.NewPriorityQueue[T](c, func(src T, dst T) int {
+		// src 来源  dst 目标
+		srcDl, srcOk := deadlineOf(src)
+		dstDl, dstOk := deadlineOf(dst)
+		// 都实现了 Deadliner 的时候直接比较到期时间，和当前时间无关
+		if srcOk && dstOk {
+			return srcDl.Compare(dstDl)
+		}
+		// 只有其中一个实现了 Deadliner 的时候才需要当前时间
+		var now time.Time
+		if srcOk || dstOk {
+			now = res.clock.Now()
+		}
+		srcDelay := delayOf(src, now)
+		dstDelay := delayOf(dst, now)
+		// 来源delay>目标delay return>0
+		if srcDelay > dstDelay {
+			return 1
+		}
+		if srcDelay == dstDelay {
+			return 0
+		}
+		// 来源delay<目标delay return<0
+		return -1
+	})
+	for _, opt := range opts {
+		opt(res)
 	}
 	return res
 }
 
+// Len 返回队列中的元素个数，包括还没有到期的元素
+// 返回值只是调用那一刻的快照，在并发入队出队的情况下，可能很快就会失效
+func (d *DelayQueue[T]) Len() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.q.Len()
+}
+
 func (d *DelayQueue[T]) Enqueue(ctx context.Context, t T) error {
 	for {
 		select {
@@ -71,11 +131,12 @@ func (d *DelayQueue[T]) Enqueue(ctx context.Context, t T) error {
 		// ctx 没有过期
 		d.mutex.Lock()
 		// 对小顶堆的优先队列进行入队操作
-		err := d.q.Enqueue(t)
+		err := d.enqueue(t)
 		switch err {
 		// 入队未发生错误
 		case nil:
 			d.enqueueSignal.broadcast()
+			d.notifyEnqueue(t)
 			return nil
 		// 队列已满
 		case queue.ErrOutOfCapacity:
@@ -93,8 +154,39 @@ func (d *DelayQueue[T]) Enqueue(ctx context.Context, t T) error {
 	}
 }
 
+// EnqueueWithTimeout 入队，队列已满的时候最多等待 timeout
+// 超时返回 ErrEnqueueTimeout，而不是 context.DeadlineExceeded
+// timeout <= 0 的时候只尝试一次，队列未满就直接入队，已满则立刻返回 ErrEnqueueTimeout
+func (d *DelayQueue[T]) EnqueueWithTimeout(t T, timeout time.Duration) error {
+	if timeout <= 0 {
+		d.mutex.Lock()
+		err := d.enqueue(t)
+		switch err {
+		case nil:
+			d.enqueueSignal.broadcast()
+			d.notifyEnqueue(t)
+			return nil
+		case queue.ErrOutOfCapacity:
+			d.mutex.Unlock()
+			return ErrEnqueueTimeout
+		default:
+			d.mutex.Unlock()
+			return fmt.Errorf("ekit: 延时队列入队的时候遇到未知错误 %w，请上报", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := d.Enqueue(ctx, t)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrEnqueueTimeout
+	}
+	return err
+}
+
 func (d *DelayQueue[T]) Dequeue(ctx context.Context) (T, error) {
-	var timer *time.Timer
+	var timer Timer
+	// timerDeadliner 表示 timer 是不是为实现了 Deadliner 的元素创建的
+	var timerDeadliner bool
 	defer func() {
 		if timer != nil {
 			timer.Stop()
@@ -112,36 +204,35 @@ func (d *DelayQueue[T]) Dequeue(ctx context.Context) (T, error) {
 		val, err := d.q.Peek()
 		switch err {
 		case nil:
-			delay := val.Delay()
+			delay := d.delay(val)
 			if delay <= 0 {
-				val, err = d.q.Dequeue()
+				val, err = d.dequeue()
 				d.dequeueSignal.broadcast()
 				// 理论上来说这里 err 不可能不为 nil
+				d.notifyDequeue(val, -delay)
 				return val, err
 			}
+			// signalCh 会释放锁，此后的所有分支都不再持有锁
 			signal := d.enqueueSignal.signalCh()
-			if timer == nil {
-				timer = time.NewTimer(delay)
+			// 没有实现 Deadliner 的元素按照真实时间到期，只能使用真实的 timer 等待，
+			// 否则在注入的时钟不推进的时候 Dequeue 永远不会被唤醒
+			_, isDeadliner := deadlineOf(val)
+			if timer == nil || timerDeadliner != isDeadliner {
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = d.timerClock(isDeadliner).NewTimer(delay)
+				timerDeadliner = isDeadliner
 			} else {
-				timer.Reset(delay)
+				resetTimer(timer, delay)
 			}
 			select {
 			case <-ctx.Done():
 				var t T
 				return t, ctx.Err()
-			case <-timer.C:
-				// 到了时间
-				d.mutex.Lock()
-				// 原队头可能已经被其他协程先出队，故再次检查队头
-				val, err := d.q.Peek()
-				if err != nil || val.Delay() > 0 {
-					d.mutex.Unlock()
-					continue
-				}
-				// 验证元素过期后将其出队
-				val, err = d.q.Dequeue()
-				d.dequeueSignal.broadcast()
-				return val, err
+			case <-timer.C():
+				// 到了时间，进入下一个循环重新加锁检查队头
+				// 原队头可能已经被其他协程先出队，所以不能直接出队
 			case <-signal:
 				// 进入下一个循环。这里可能是有新的元素入队，也可能是到期了
 			}
@@ -161,10 +252,240 @@ func (d *DelayQueue[T]) Dequeue(ctx context.Context) (T, error) {
 	}
 }
 
+// TryDequeue 尝试出队一个已经到期的元素，不会阻塞等待
+// 如果队列为空，返回 ErrEmptyQueue；如果队首元素还没有到期，返回 ErrNoReadyElement
+func (d *DelayQueue[T]) TryDequeue(ctx context.Context) (T, error) {
+	select {
+	case <-ctx.Done():
+		var t T
+		return t, ctx.Err()
+	default:
+	}
+	d.mutex.Lock()
+	val, err := d.q.Peek()
+	if err != nil {
+		d.mutex.Unlock()
+		var t T
+		return t, err
+	}
+	delay := d.delay(val)
+	if delay > 0 {
+		d.mutex.Unlock()
+		var t T
+		return t, ErrNoReadyElement
+	}
+	val, err = d.dequeue()
+	d.dequeueSignal.broadcast()
+	d.notifyDequeue(val, -delay)
+	return val, err
+}
+
+// DequeueExpired 批量出队已经到期的元素，最多 limit 个，limit <= 0 时表示不限制个数
+// 如果队列中没有到期的元素，会和 Dequeue 一样阻塞，直到至少有一个元素到期或者 ctx 过期；
+// 拿到第一个元素之后，不会再阻塞，只会继续取出此刻已经到期的元素
+func (d *DelayQueue[T]) DequeueExpired(ctx context.Context, limit int) ([]T, error) {
+	first, err := d.Dequeue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := []T{first}
+	// 第一个元素已经在 Dequeue 里面通知过了
+	var delays []time.Duration
+	d.mutex.Lock()
+	for limit <= 0 || len(res) < limit {
+		val, err := d.q.Peek()
+		if err != nil {
+			break
+		}
+		delay := d.delay(val)
+		if delay > 0 {
+			break
+		}
+		val, _ = d.dequeue()
+		res = append(res, val)
+		if d.observer != nil {
+			delays = append(delays, delay)
+		}
+	}
+	if len(res) == 1 {
+		d.mutex.Unlock()
+		return res, nil
+	}
+	d.dequeueSignal.broadcast()
+	for i, delay := range delays {
+		d.notifyDequeue(res[i+1], -delay)
+	}
+	return res, nil
+}
+
+// Remove 删除第一个满足 match 的元素，返回被删除的元素和 true，可以用于取消还没有到期的元素
+// 如果没有满足条件的元素，返回零值和 false
+// 删除成功之后会发出出队信号，唤醒因为队列已满而阻塞的 Enqueue，
+// 同时也会唤醒正在等待的 Dequeue，让它按照新的队头重新设置 timer
+func (d *DelayQueue[T]) Remove(match func(T) bool) (T, bool) {
+	d.mutex.Lock()
+	val, ok := d.remove(match)
+	if !ok {
+		d.mutex.Unlock()
+		return val, false
+	}
+	d.dequeueSignal.broadcast()
+	// broadcast 会释放锁，所以需要重新加锁
+	d.mutex.Lock()
+	d.enqueueSignal.broadcast()
+	return val, true
+}
+
+// DrainAll 取出队列中的所有元素，按照到期时间从早到晚排列，不管它们有没有到期
+// 这个方法故意绕过了延时的语义，适合在关闭服务的时候一次性处理掉剩余的元素
+// 取出之后会发出出队信号，唤醒因为队列已满而阻塞的 Enqueue，
+// 同时也会唤醒正在等待的 Dequeue，让它发现队列为空，转而等待新的元素
+// 队列为空的时候返回一个空切片而不是 nil
+func (d *DelayQueue[T]) DrainAll() []T {
+	d.mutex.Lock()
+	res := make([]T, 0, d.q.Len())
+	for {
+		val, err := d.dequeue()
+		if err != nil {
+			break
+		}
+		res = append(res, val)
+	}
+	if len(res) == 0 {
+		d.mutex.Unlock()
+		return res
+	}
+	d.dequeueSignal.broadcast()
+	// broadcast 会释放锁，所以需要重新加锁
+	d.mutex.Lock()
+	d.enqueueSignal.broadcast()
+	return res
+}
+
+// PeekDelay 返回队首元素距离到期还有多久，不会出队，也不会阻塞
+// 如果队首元素已经到期，返回值小于等于 0
+// 如果队列为空，返回 ErrEmptyQueue
+func (d *DelayQueue[T]) PeekDelay() (time.Duration, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	val, err := d.q.Peek()
+	if err != nil {
+		return 0, err
+	}
+	return d.delay(val), nil
+}
+
+// delay 根据延时队列的时钟计算元素的延时
+func (d *DelayQueue[T]) delay(t T) time.Duration {
+	return delayOf(t, d.clock.Now())
+}
+
+// timerClock 返回等待元素到期的时候用来创建 timer 的时钟
+// 只有实现了 Deadliner 的元素才根据注入的时钟到期，其余元素使用真实时钟
+func (d *DelayQueue[T]) timerClock(isDeadliner bool) Clock {
+	if isDeadliner {
+		return d.clock
+	}
+	return RealClock()
+}
+
+// enqueue 将元素放入堆中，必须持有锁
+// 对于 NewDelayQueueDedup 创建的队列，如果已经存在 key 相同的元素，会直接替换它，不会占用新的容量
+func (d *DelayQueue[T]) enqueue(t T) error {
+	if d.index == nil {
+		return d.q.Enqueue(t)
+	}
+	if d.index.replace(&d.q, t) {
+		return nil
+	}
+	if err := d.q.Enqueue(t); err != nil {
+		return err
+	}
+	d.index.add(t)
+	return nil
+}
+
+// dequeue 从堆中取出队首元素，必须持有锁
+func (d *DelayQueue[T]) dequeue() (T, error) {
+	val, err := d.q.Dequeue()
+	if err == nil && d.index != nil {
+		d.index.delete(val)
+	}
+	return val, err
+}
+
+// remove 从堆中删除第一个满足 match 的元素，必须持有锁
+func (d *DelayQueue[T]) remove(match func(T) bool) (T, bool) {
+	val, ok := d.q.Remove(match)
+	if ok && d.index != nil {
+		d.index.delete(val)
+	}
+	return val, ok
+}
+
+// notifyEnqueue 通知观察者有元素入队，必须在锁范围之外调用
+func (d *DelayQueue[T]) notifyEnqueue(t T) {
+	if d.observer != nil {
+		d.observer.OnEnqueue(t)
+	}
+}
+
+// notifyDequeue 通知观察者有元素出队，必须在锁范围之外调用
+func (d *DelayQueue[T]) notifyDequeue(t T, lateness time.Duration) {
+	if d.observer != nil {
+		d.observer.OnDequeue(t, lateness)
+	}
+}
+
+// resetTimer 重置 timer
+// 如果 timer 已经触发但是还没有被读取，需要先清空 timer.C，否则下一次等待会立刻返回
+func resetTimer(timer Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C():
+		default:
+		}
+	}
+	timer.Reset(d)
+}
+
 type Delayable interface {
 	Delay() time.Duration
 }
 
+// Deadliner 是 Delayable 的补充，返回元素的到期时间点
+// 延时队列会使用 Deadline() 减去 Clock 的当前时间作为元素的延时，
+// 这样元素自身不需要知道时钟，延时队列注入的时钟就能生效
+type Deadliner interface {
+	Deadline() time.Time
+}
+
+// optionalDeadliner 由 JitterDelayable 这类包装类型实现，
+// 只有被包装的元素实现了 Deadliner 的时候才有到期时间，优先级高于 Deadliner
+type optionalDeadliner interface {
+	deadline() (time.Time, bool)
+}
+
+// deadlineOf 返回元素的到期时间，元素没有实现 Deadliner 的时候返回 false
+func deadlineOf[T Delayable](t T) (time.Time, bool) {
+	switch dl := any(t).(type) {
+	case optionalDeadliner:
+		return dl.deadline()
+	case Deadliner:
+		return dl.Deadline(), true
+	}
+	return time.Time{}, false
+}
+
+// delayOf 计算元素相对于 now 的延时
+// 实现了 Deadliner 的元素根据 Deadline() 计算，否则直接使用 Delay()
+func delayOf[T Delayable](t T, now time.Time) time.Duration {
+	if dl, ok := deadlineOf(t); ok {
+		return dl.Sub(now)
+	}
+	return t.Delay()
+}
+
 type cond struct {
 	signal chan struct{}
 	l      sync.Locker
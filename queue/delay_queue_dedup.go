@@ -0,0 +1,68 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import "github.com/go-generic/internal/queue"
+
+// Keyed 带有 key 的 Delayable，NewDelayQueueDedup 创建的延时队列使用 key 来合并重复的元素
+type Keyed[K comparable] interface {
+	Delayable
+	Key() K
+}
+
+// NewDelayQueueDedup 创建一个会合并重复 key 的延时队列
+// 入队的时候，如果队列中已经存在 key 相同的元素，那么会用新元素替换旧元素，并且按照新元素的延时调整它的位置，
+// 而不是再放入一个新元素。替换不会占用新的容量，所以即使队列已满，替换也不会阻塞
+// 其余的行为和 NewDelayQueue 创建的延时队列完全一致
+// 注意：替换需要在堆中查找旧元素，时间复杂度是 O(n)
+func NewDelayQueueDedup[K comparable, T Keyed[K]](c int, opts ...DelayQueueOption[T]) *DelayQueue[T] {
+	res := NewDelayQueue[T](c, opts...)
+	res.index = &dedupIndex[K, T]{
+		keys: make(map[K]struct{}, max(c, 0)),
+	}
+	return res
+}
+
+// keyIndex 记录队列中已经存在的 key
+type keyIndex[T any] interface {
+	// replace 如果已经存在 key 相同的元素，用 t 替换它并返回 true
+	replace(q *queue.PriorityQueue[T], t T) bool
+	// add 记录 t 的 key，t 必须已经放入堆中
+	add(t T)
+	// delete 删除 t 的 key，t 必须已经从堆中删除
+	delete(t T)
+}
+
+type dedupIndex[K comparable, T Keyed[K]] struct {
+	keys map[K]struct{}
+}
+
+func (d *dedupIndex[K, T]) replace(q *queue.PriorityQueue[T], t T) bool {
+	key := t.Key()
+	if _, ok := d.keys[key]; !ok {
+		return false
+	}
+	return q.Update(func(src T) bool {
+		return src.Key() == key
+	}, t)
+}
+
+func (d *dedupIndex[K, T]) add(t T) {
+	d.keys[t.Key()] = struct{}{}
+}
+
+func (d *dedupIndex[K, T]) delete(t T) {
+	delete(d.keys, t.Key())
+}
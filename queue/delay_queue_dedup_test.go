@@ -0,0 +1,205 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelayQueueDedup_Enqueue(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	testCases := []struct {
+		name     string
+		capacity int
+		eles     []keyedElem
+		wantLen  int
+		wantVals []int
+	}{
+		{
+			name:     "no duplicate",
+			capacity: 3,
+			eles: []keyedElem{
+				{key: "a", val: 1, deadline: now.Add(time.Hour)},
+				{key: "b", val: 2, deadline: now.Add(2 * time.Hour)},
+			},
+			wantLen:  2,
+			wantVals: []int{1, 2},
+		},
+		{
+			name:     "replace with earlier deadline",
+			capacity: 3,
+			eles: []keyedElem{
+				{key: "a", val: 1, deadline: now.Add(time.Hour)},
+				{key: "b", val: 2, deadline: now.Add(2 * time.Hour)},
+				{key: "b", val: 3, deadline: now.Add(time.Minute)},
+			},
+			wantLen:  2,
+			wantVals: []int{3, 1},
+		},
+		{
+			name:     "replace with later deadline",
+			capacity: 3,
+			eles: []keyedElem{
+				{key: "a", val: 1, deadline: now.Add(time.Minute)},
+				{key: "b", val: 2, deadline: now.Add(time.Hour)},
+				{key: "a", val: 3, deadline: now.Add(2 * time.Hour)},
+			},
+			wantLen:  2,
+			wantVals: []int{2, 3},
+		},
+		{
+			name:     "replace when full",
+			capacity: 1,
+			eles: []keyedElem{
+				{key: "a", val: 1, deadline: now.Add(time.Minute)},
+				{key: "a", val: 2, deadline: now.Add(time.Hour)},
+			},
+			wantLen:  1,
+			wantVals: []int{2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewDelayQueueDedup[string, keyedElem](tc.capacity)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			for _, ele := range tc.eles {
+				require.NoError(t, q.Enqueue(ctx, ele))
+			}
+			assert.Equal(t, tc.wantLen, q.Len())
+			vals := make([]int, 0, tc.wantLen)
+			for _, ele := range q.DrainAll() {
+				vals = append(vals, ele.val)
+			}
+			assert.Equal(t, tc.wantVals, vals)
+		})
+	}
+}
+
+func TestDelayQueueDedup_RemoveKey(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	// 元素通过各种方式离开队列之后，同样的 key 应该可以再次入队，而不是被当成重复的元素
+	testCases := []struct {
+		name   string
+		remove func(t *testing.T, q *DelayQueue[keyedElem])
+	}{
+		{
+			name: "Dequeue",
+			remove: func(t *testing.T, q *DelayQueue[keyedElem]) {
+				_, err := q.Dequeue(context.Background())
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "TryDequeue",
+			remove: func(t *testing.T, q *DelayQueue[keyedElem]) {
+				_, err := q.TryDequeue(context.Background())
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "DequeueExpired",
+			remove: func(t *testing.T, q *DelayQueue[keyedElem]) {
+				_, err := q.DequeueExpired(context.Background(), 0)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Remove",
+			remove: func(t *testing.T, q *DelayQueue[keyedElem]) {
+				_, ok := q.Remove(func(ele keyedElem) bool {
+					return ele.key == "a"
+				})
+				require.True(t, ok)
+			},
+		},
+		{
+			name: "DrainAll",
+			remove: func(t *testing.T, q *DelayQueue[keyedElem]) {
+				q.DrainAll()
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewDelayQueueDedup[string, keyedElem](2)
+			require.NoError(t, q.Enqueue(context.Background(),
+				keyedElem{key: "a", val: 1, deadline: now.Add(-time.Second)}))
+			tc.remove(t, q)
+			assert.Equal(t, 0, q.Len())
+
+			require.NoError(t, q.Enqueue(context.Background(),
+				keyedElem{key: "a", val: 2, deadline: now.Add(time.Hour)}))
+			require.NoError(t, q.Enqueue(context.Background(),
+				keyedElem{key: "a", val: 3, deadline: now.Add(time.Hour)}))
+			assert.Equal(t, 1, q.Len())
+		})
+	}
+}
+
+func TestDelayQueueDedup_WakeUpDequeue(t *testing.T) {
+	t.Parallel()
+	q := NewDelayQueueDedup[string, keyedElem](2)
+	require.NoError(t, q.Enqueue(context.Background(),
+		keyedElem{key: "a", val: 1, deadline: time.Now().Add(time.Hour)}))
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		// 提前 a 的到期时间，阻塞的 Dequeue 应该被唤醒
+		_ = q.Enqueue(context.Background(),
+			keyedElem{key: "a", val: 2, deadline: time.Now().Add(100 * time.Millisecond)})
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	ele, err := q.Dequeue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, ele.val)
+	assert.Equal(t, 0, q.Len())
+}
+
+type keyedElem struct {
+	key      string
+	deadline time.Time
+	val      int
+}
+
+func (k keyedElem) Delay() time.Duration {
+	return time.Until(k.deadline)
+}
+
+func (k keyedElem) Key() string {
+	return k.key
+}
+
+func ExampleNewDelayQueueDedup() {
+	q := NewDelayQueueDedup[string, keyedElem](10)
+	now := time.Now()
+	_ = q.Enqueue(context.Background(), keyedElem{key: "job", val: 1, deadline: now.Add(time.Hour)})
+	// 同一个 key 再次入队，会替换掉之前的元素
+	_ = q.Enqueue(context.Background(), keyedElem{key: "job", val: 2, deadline: now.Add(-time.Second)})
+	fmt.Println(q.Len())
+	ele, _ := q.TryDequeue(context.Background())
+	fmt.Println(ele.val)
+	// Output:
+	// 1
+	// 2
+}
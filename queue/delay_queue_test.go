@@ -17,6 +17,7 @@ package queue
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -200,6 +201,56 @@ func TestDelayQueue_Dequeue(t *testing.T) {
 	})
 }
 
+// 大量协程并发入队出队，元素的延时时间很短
+// 所有路径都必须正确释放锁，否则会出现死锁，导致出队超时
+func TestDelayQueue_EnqueueDequeueConcurrently(t *testing.T) {
+	t.Parallel()
+	const producers, perProducer = 20, 50
+	q := NewDelayQueue[delayElem](10)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	var eg errgroup.Group
+	for i := 0; i < producers; i++ {
+		i := i
+		eg.Go(func() error {
+			for j := 0; j < perProducer; j++ {
+				err := q.Enqueue(ctx, delayElem{
+					val:      i*perProducer + j,
+					deadline: time.Now().Add(time.Duration(j%5) * time.Millisecond),
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	resultChan := make(chan int, producers*perProducer)
+	for i := 0; i < producers; i++ {
+		eg.Go(func() error {
+			for j := 0; j < perProducer; j++ {
+				ele, err := q.Dequeue(ctx)
+				if err != nil {
+					return err
+				}
+				if ele.deadline.After(time.Now()) {
+					return fmt.Errorf("元素 %d 还没有到期就出队了", ele.val)
+				}
+				resultChan <- ele.val
+			}
+			return nil
+		})
+	}
+	require.NoError(t, eg.Wait())
+	close(resultChan)
+	resultSet := make(map[int]struct{}, producers*perProducer)
+	for val := range resultChan {
+		resultSet[val] = struct{}{}
+	}
+	assert.Equal(t, producers*perProducer, len(resultSet))
+	assert.Equal(t, 0, q.Len())
+}
+
 func TestDelayQueue_Enqueue(t *testing.T) {
 	t.Parallel()
 	now := time.Now()
@@ -292,6 +343,636 @@ func TestDelayQueue_Enqueue(t *testing.T) {
 	})
 }
 
+func TestDelayQueue_EnqueueWithTimeout(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	testCases := []struct {
+		name    string
+		q       *DelayQueue[delayElem]
+		timeout time.Duration
+		wantErr error
+		wantLen int
+	}{
+		{
+			name:    "not full",
+			q:       NewDelayQueue[delayElem](2),
+			timeout: time.Second,
+			wantLen: 1,
+		},
+		{
+			name: "full and timeout",
+			q: newDelayQueue(t, delayElem{
+				deadline: now.Add(time.Minute),
+				val:      1,
+			}),
+			timeout: time.Millisecond * 100,
+			wantErr: ErrEnqueueTimeout,
+			wantLen: 1,
+		},
+		{
+			name: "invalid timeout",
+			q: newDelayQueue(t, delayElem{
+				deadline: now.Add(time.Minute),
+				val:      1,
+			}),
+			timeout: -time.Second,
+			wantErr: ErrEnqueueTimeout,
+			wantLen: 1,
+		},
+		{
+			name:    "zero timeout and not full",
+			q:       NewDelayQueue[delayElem](2),
+			timeout: 0,
+			wantLen: 1,
+		},
+		{
+			name: "zero timeout and full",
+			q: newDelayQueue(t, delayElem{
+				deadline: now.Add(time.Minute),
+				val:      1,
+			}),
+			timeout: 0,
+			wantErr: ErrEnqueueTimeout,
+			wantLen: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.q.EnqueueWithTimeout(delayElem{
+				deadline: now.Add(time.Minute),
+				val:      2,
+			}, tc.timeout)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantLen, tc.q.Len())
+		})
+	}
+}
+
+func TestDelayQueue_TryDequeue(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	testCases := []struct {
+		name    string
+		q       *DelayQueue[delayElem]
+		timeout time.Duration
+		wantVal int
+		wantErr error
+	}{
+		{
+			name: "dequeued",
+			q: newDelayQueue(t, delayElem{
+				deadline: now.Add(-time.Millisecond * 10),
+				val:      11,
+			}),
+			timeout: time.Second,
+			wantVal: 11,
+		},
+		{
+			name: "not ready",
+			q: newDelayQueue(t, delayElem{
+				deadline: now.Add(time.Minute),
+				val:      11,
+			}),
+			timeout: time.Second,
+			wantErr: ErrNoReadyElement,
+		},
+		{
+			name:    "empty",
+			q:       NewDelayQueue[delayElem](3),
+			timeout: time.Second,
+			wantErr: ErrEmptyQueue,
+		},
+		{
+			name: "invalid context",
+			q: newDelayQueue(t, delayElem{
+				deadline: now.Add(-time.Millisecond * 10),
+				val:      11,
+			}),
+			timeout: -time.Second,
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			start := time.Now()
+			ele, err := tc.q.TryDequeue(ctx)
+			// 不应该阻塞
+			assert.Less(t, time.Since(start), time.Millisecond*100)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantVal, ele.val)
+		})
+	}
+}
+
+func TestDelayQueue_Len(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	q := NewDelayQueue[delayElem](3)
+	assert.Equal(t, 0, q.Len())
+	err := q.Enqueue(context.Background(), delayElem{val: 1, deadline: now.Add(-time.Second)})
+	require.NoError(t, err)
+	err = q.Enqueue(context.Background(), delayElem{val: 2, deadline: now.Add(time.Minute)})
+	require.NoError(t, err)
+	// 未到期的元素也会被计算在内
+	assert.Equal(t, 2, q.Len())
+	_, err = q.TryDequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, q.Len())
+}
+
+func TestDelayQueue_PeekDelay(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	testCases := []struct {
+		name      string
+		q         *DelayQueue[delayElem]
+		wantDelay func(d time.Duration) bool
+		wantErr   error
+	}{
+		{
+			name:    "empty",
+			q:       NewDelayQueue[delayElem](3),
+			wantErr: ErrEmptyQueue,
+		},
+		{
+			name: "not ready",
+			q: newDelayQueue(t, delayElem{
+				deadline: now.Add(time.Minute),
+				val:      1,
+			}, delayElem{
+				deadline: now.Add(time.Hour),
+				val:      2,
+			}),
+			wantDelay: func(d time.Duration) bool {
+				return d > 0 && d <= time.Minute
+			},
+		},
+		{
+			name: "already deadline",
+			q: newDelayQueue(t, delayElem{
+				deadline: now.Add(-time.Minute),
+				val:      1,
+			}),
+			wantDelay: func(d time.Duration) bool {
+				return d <= -time.Minute
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := tc.q.Len()
+			delay, err := tc.q.PeekDelay()
+			assert.Equal(t, tc.wantErr, err)
+			// 不会出队
+			assert.Equal(t, l, tc.q.Len())
+			if err != nil {
+				return
+			}
+			assert.True(t, tc.wantDelay(delay))
+		})
+	}
+}
+
+func TestDelayQueue_DequeueExpired(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	newQueue := func() *DelayQueue[delayElem] {
+		return newDelayQueue(t, delayElem{
+			deadline: now.Add(-time.Second * 3),
+			val:      1,
+		}, delayElem{
+			deadline: now.Add(-time.Second * 2),
+			val:      2,
+		}, delayElem{
+			deadline: now.Add(-time.Second),
+			val:      3,
+		}, delayElem{
+			deadline: now.Add(time.Minute),
+			val:      4,
+		})
+	}
+	testCases := []struct {
+		name     string
+		q        *DelayQueue[delayElem]
+		timeout  time.Duration
+		max      int
+		wantVals []int
+		wantLen  int
+		wantErr  error
+	}{
+		{
+			name:     "all expired",
+			q:        newQueue(),
+			timeout:  time.Second,
+			wantVals: []int{1, 2, 3},
+			wantLen:  1,
+		},
+		{
+			name:     "max",
+			q:        newQueue(),
+			timeout:  time.Second,
+			max:      2,
+			wantVals: []int{1, 2},
+			wantLen:  2,
+		},
+		{
+			name:     "max one",
+			q:        newQueue(),
+			timeout:  time.Second,
+			max:      1,
+			wantVals: []int{1},
+			wantLen:  3,
+		},
+		{
+			name: "wait for the first",
+			q: newDelayQueue(t, delayElem{
+				deadline: now.Add(time.Millisecond * 100),
+				val:      1,
+			}, delayElem{
+				deadline: now.Add(time.Minute),
+				val:      2,
+			}),
+			timeout:  time.Second,
+			wantVals: []int{1},
+			wantLen:  1,
+		},
+		{
+			name: "timeout",
+			q: newDelayQueue(t, delayElem{
+				deadline: now.Add(time.Minute),
+				val:      1,
+			}),
+			timeout: time.Millisecond * 100,
+			wantErr: context.DeadlineExceeded,
+			wantLen: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			eles, err := tc.q.DequeueExpired(ctx, tc.max)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantLen, tc.q.Len())
+			if err != nil {
+				return
+			}
+			vals := make([]int, 0, len(eles))
+			for _, ele := range eles {
+				vals = append(vals, ele.val)
+			}
+			assert.Equal(t, tc.wantVals, vals)
+		})
+	}
+}
+
+func TestDelayQueue_Remove(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	newQueue := func() *DelayQueue[delayElem] {
+		return newDelayQueue(t, delayElem{
+			deadline: now.Add(time.Minute),
+			val:      1,
+		}, delayElem{
+			deadline: now.Add(time.Minute * 2),
+			val:      2,
+		}, delayElem{
+			deadline: now.Add(time.Minute * 3),
+			val:      3,
+		}, delayElem{
+			deadline: now.Add(time.Minute * 4),
+			val:      4,
+		})
+	}
+	testCases := []struct {
+		name     string
+		q        *DelayQueue[delayElem]
+		val      int
+		wantOk   bool
+		wantVals []int
+	}{
+		{
+			name:     "head",
+			q:        newQueue(),
+			val:      1,
+			wantOk:   true,
+			wantVals: []int{2, 3, 4},
+		},
+		{
+			name:     "middle",
+			q:        newQueue(),
+			val:      3,
+			wantOk:   true,
+			wantVals: []int{1, 2, 4},
+		},
+		{
+			name:     "not found",
+			q:        newQueue(),
+			val:      5,
+			wantVals: []int{1, 2, 3, 4},
+		},
+		{
+			name:     "empty",
+			q:        NewDelayQueue[delayElem](3),
+			val:      1,
+			wantVals: []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ele, ok := tc.q.Remove(func(ele delayElem) bool {
+				return ele.val == tc.val
+			})
+			assert.Equal(t, tc.wantOk, ok)
+			if ok {
+				assert.Equal(t, tc.val, ele.val)
+			}
+			assert.Equal(t, len(tc.wantVals), tc.q.Len())
+			// 剩下的元素依旧按照到期时间排序，每次都删除堆顶的元素
+			vals := make([]int, 0, len(tc.wantVals))
+			for tc.q.Len() > 0 {
+				ele, ok = tc.q.Remove(func(delayElem) bool {
+					return true
+				})
+				require.True(t, ok)
+				vals = append(vals, ele.val)
+			}
+			assert.Equal(t, tc.wantVals, vals)
+		})
+	}
+
+	// 删除之后，Dequeue 返回的是新的队头
+	t.Run("dequeue after removing head", func(t *testing.T) {
+		q := newDelayQueue(t, delayElem{
+			deadline: now.Add(time.Minute),
+			val:      1,
+		}, delayElem{
+			deadline: now.Add(-time.Second),
+			val:      2,
+		}, delayElem{
+			deadline: now.Add(-time.Minute),
+			val:      3,
+		})
+		ele, ok := q.Remove(func(ele delayElem) bool {
+			return ele.val == 3
+		})
+		require.True(t, ok)
+		assert.Equal(t, 3, ele.val)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		ele, err := q.Dequeue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, ele.val)
+	})
+
+	// 队列已满，删除元素之后阻塞的 Enqueue 可以继续
+	t.Run("enqueue while removing", func(t *testing.T) {
+		q := newDelayQueue(t, delayElem{
+			deadline: now.Add(time.Minute),
+			val:      1,
+		})
+		go func() {
+			time.Sleep(time.Millisecond * 100)
+			_, ok := q.Remove(func(ele delayElem) bool {
+				return ele.val == 1
+			})
+			assert.True(t, ok)
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err := q.Enqueue(ctx, delayElem{
+			deadline: now.Add(time.Minute),
+			val:      2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, q.Len())
+	})
+}
+
+func TestDelayQueue_DrainAll(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	testCases := []struct {
+		name     string
+		q        *DelayQueue[delayElem]
+		wantVals []int
+	}{
+		{
+			name:     "empty",
+			q:        newDelayQueue(t),
+			wantVals: []int{},
+		},
+		{
+			name: "mixed",
+			q: newDelayQueue(t,
+				delayElem{val: 3, deadline: now.Add(time.Hour)},
+				delayElem{val: 1, deadline: now.Add(-time.Second)},
+				delayElem{val: 4, deadline: now.Add(2 * time.Hour)},
+				delayElem{val: 2, deadline: now.Add(time.Minute)},
+			),
+			wantVals: []int{1, 2, 3, 4},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.q.DrainAll()
+			vals := make([]int, 0, len(res))
+			for _, ele := range res {
+				vals = append(vals, ele.val)
+			}
+			assert.Equal(t, tc.wantVals, vals)
+			assert.Equal(t, 0, tc.q.Len())
+		})
+	}
+
+	t.Run("wake up enqueue", func(t *testing.T) {
+		q := newDelayQueue(t, delayElem{val: 1, deadline: now.Add(time.Hour)})
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			q.DrainAll()
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err := q.Enqueue(ctx, delayElem{val: 2, deadline: now.Add(time.Hour)})
+		require.NoError(t, err)
+		assert.Equal(t, 1, q.Len())
+	})
+
+	// 队列已满，同时有阻塞的 Enqueue 和 Dequeue，DrainAll 之后两者都可以继续
+	t.Run("wake up enqueue and dequeue", func(t *testing.T) {
+		q := newDelayQueue(t, delayElem{val: 1, deadline: now.Add(time.Hour)})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		var eg errgroup.Group
+		eg.Go(func() error {
+			ele, err := q.Dequeue(ctx)
+			if err != nil {
+				return err
+			}
+			assert.Equal(t, 2, ele.val)
+			return nil
+		})
+		eg.Go(func() error {
+			return q.Enqueue(ctx, delayElem{val: 2, deadline: now.Add(time.Millisecond * 200)})
+		})
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, []delayElem{{val: 1, deadline: now.Add(time.Hour)}}, q.DrainAll())
+		require.NoError(t, eg.Wait())
+		assert.Equal(t, 0, q.Len())
+	})
+}
+
+func TestDelayQueue_Observer(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	observer := &recordObserver{}
+	q := NewDelayQueue[delayElem](0, WithObserver[delayElem](observer))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i, deadline := range []time.Time{
+		now.Add(-time.Minute),
+		now.Add(-time.Second),
+		now.Add(-time.Millisecond),
+		now.Add(time.Millisecond * 100),
+		now.Add(time.Minute),
+	} {
+		require.NoError(t, q.Enqueue(ctx, delayElem{deadline: deadline, val: i}))
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, observer.enqueued)
+
+	// 每一种出队的方式都会通知观察者
+	_, err := q.TryDequeue(ctx)
+	require.NoError(t, err)
+	_, err = q.DequeueExpired(ctx, 0)
+	require.NoError(t, err)
+	_, err = q.Dequeue(ctx)
+	require.NoError(t, err)
+	// 删除不会通知观察者
+	_, ok := q.Remove(func(ele delayElem) bool {
+		return ele.val == 4
+	})
+	require.True(t, ok)
+
+	assert.Equal(t, []int{0, 1, 2, 3}, observer.dequeued)
+	require.Len(t, observer.lateness, 4)
+	assert.GreaterOrEqual(t, observer.lateness[0], time.Minute)
+	assert.GreaterOrEqual(t, observer.lateness[1], time.Second)
+	assert.GreaterOrEqual(t, observer.lateness[2], time.Millisecond)
+	// 阻塞等到到期之后才出队，lateness 很小，但是不会小于 0
+	assert.GreaterOrEqual(t, observer.lateness[3], time.Duration(0))
+	assert.Less(t, observer.lateness[3], time.Second)
+}
+
+// recordObserver 记录所有的回调，用于测试
+type recordObserver struct {
+	mutex    sync.Mutex
+	enqueued []int
+	dequeued []int
+	lateness []time.Duration
+}
+
+func (r *recordObserver) OnEnqueue(t delayElem) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.enqueued = append(r.enqueued, t.val)
+}
+
+func (r *recordObserver) OnDequeue(t delayElem, lateness time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.dequeued = append(r.dequeued, t.val)
+	r.lateness = append(r.lateness, lateness)
+}
+
+func TestDelayQueue_WithClock(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("dequeue after advance", func(t *testing.T) {
+		clock := newFakeClock(start)
+		q := NewDelayQueue[deadlineElem](10, WithClock[deadlineElem](clock))
+		require.NoError(t, q.Enqueue(context.Background(), deadlineElem{deadline: start.Add(time.Second), val: 1}))
+
+		delay, err := q.PeekDelay()
+		require.NoError(t, err)
+		assert.Equal(t, time.Second, delay)
+		_, err = q.TryDequeue(context.Background())
+		assert.Equal(t, ErrNoReadyElement, err)
+
+		type result struct {
+			val deadlineElem
+			err error
+		}
+		ch := make(chan result, 1)
+		go func() {
+			val, err := q.Dequeue(context.Background())
+			ch <- result{val: val, err: err}
+		}()
+		// 等 Dequeue 创建好 timer 之后再推进时钟
+		require.Eventually(t, func() bool {
+			return clock.activeTimers() == 1
+		}, time.Second, time.Millisecond)
+
+		clock.Advance(500 * time.Millisecond)
+		delay, err = q.PeekDelay()
+		require.NoError(t, err)
+		assert.Equal(t, 500*time.Millisecond, delay)
+		select {
+		case <-ch:
+			t.Fatal("元素还没有到期，不应该出队")
+		default:
+		}
+
+		clock.Advance(600 * time.Millisecond)
+		res := <-ch
+		require.NoError(t, res.err)
+		assert.Equal(t, 1, res.val.val)
+	})
+
+	t.Run("order by deadline", func(t *testing.T) {
+		clock := newFakeClock(start)
+		q := NewDelayQueue[deadlineElem](10, WithClock[deadlineElem](clock))
+		for _, i := range []int{3, 1, 2} {
+			require.NoError(t, q.Enqueue(context.Background(),
+				deadlineElem{deadline: start.Add(time.Duration(i) * time.Second), val: i}))
+		}
+		clock.Advance(2 * time.Second)
+		vals, err := q.DequeueExpired(context.Background(), 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, []int{vals[0].val, vals[1].val})
+		assert.Len(t, vals, 2)
+		assert.Equal(t, 1, q.Len())
+	})
+
+	t.Run("element without deadline", func(t *testing.T) {
+		// 没有实现 Deadliner 的元素依旧使用 Delay()，不受时钟影响
+		clock := newFakeClock(start)
+		q := NewDelayQueue[delayElem](10, WithClock[delayElem](clock))
+		require.NoError(t, q.Enqueue(context.Background(), delayElem{deadline: time.Now().Add(100 * time.Millisecond), val: 1}))
+		clock.Advance(2 * time.Hour)
+		_, err := q.TryDequeue(context.Background())
+		assert.Equal(t, ErrNoReadyElement, err)
+
+		// 时钟不再推进，Dequeue 依旧会按照真实时间被唤醒
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		ele, err := q.Dequeue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, ele.val)
+		assert.Equal(t, 0, clock.activeTimers())
+	})
+}
+
 func newDelayQueue(t *testing.T, eles ...delayElem) *DelayQueue[delayElem] {
 	q := NewDelayQueue[delayElem](len(eles))
 	for _, ele := range eles {
@@ -310,6 +991,20 @@ func (d delayElem) Delay() time.Duration {
 	return time.Until(d.deadline)
 }
 
+// deadlineElem 实现了 Deadliner，延时由队列的时钟计算
+type deadlineElem struct {
+	deadline time.Time
+	val      int
+}
+
+func (d deadlineElem) Delay() time.Duration {
+	return time.Until(d.deadline)
+}
+
+func (d deadlineElem) Deadline() time.Time {
+	return d.deadline
+}
+
 func ExampleNewDelayQueue() {
 	q := NewDelayQueue[delayElem](10)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
@@ -0,0 +1,79 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"sync"
+
+	"github.com/go-generic/internal/list"
+)
+
+// Deque 并发安全的双端队列，基于双向链表实现，无界
+// 两端都可以入队和出队，时间复杂度都是 O(1)
+type Deque[T any] struct {
+	// 链表头部是队首元素，尾部是队尾元素
+	l     *list.LinkedList[T]
+	mutex sync.Mutex
+}
+
+// NewDeque 创建一个新的双端队列
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{
+		l: list.NewLinkedList[T](),
+	}
+}
+
+// PushFront 在队首插入元素
+func (d *Deque[T]) PushFront(t T) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.l.PushFront(t)
+}
+
+// PushBack 在队尾插入元素
+func (d *Deque[T]) PushBack(t T) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.l.PushBack(t)
+}
+
+// PopFront 移除并返回队首元素，如果队列为空，返回 ErrEmptyQueue
+func (d *Deque[T]) PopFront() (T, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.l.Len() == 0 {
+		var t T
+		return t, ErrEmptyQueue
+	}
+	return d.l.Remove(d.l.Front()), nil
+}
+
+// PopBack 移除并返回队尾元素，如果队列为空，返回 ErrEmptyQueue
+func (d *Deque[T]) PopBack() (T, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.l.Len() == 0 {
+		var t T
+		return t, ErrEmptyQueue
+	}
+	return d.l.Remove(d.l.Back()), nil
+}
+
+// Len 返回队列中的元素个数
+func (d *Deque[T]) Len() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.l.Len()
+}
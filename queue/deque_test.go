@@ -0,0 +1,213 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeque_Push(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		push     func(d *Deque[int])
+		wantData []int
+	}{
+		{
+			name:     "empty",
+			push:     func(d *Deque[int]) {},
+			wantData: []int{},
+		},
+		{
+			name: "push front",
+			push: func(d *Deque[int]) {
+				d.PushFront(1)
+				d.PushFront(2)
+				d.PushFront(3)
+			},
+			wantData: []int{3, 2, 1},
+		},
+		{
+			name: "push back",
+			push: func(d *Deque[int]) {
+				d.PushBack(1)
+				d.PushBack(2)
+				d.PushBack(3)
+			},
+			wantData: []int{1, 2, 3},
+		},
+		{
+			name: "push both",
+			push: func(d *Deque[int]) {
+				d.PushBack(2)
+				d.PushFront(1)
+				d.PushBack(3)
+			},
+			wantData: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDeque[int]()
+			tc.push(d)
+			assert.Equal(t, tc.wantData, d.asSlice())
+			assert.Equal(t, len(tc.wantData), d.Len())
+		})
+	}
+}
+
+func TestDeque_Pop(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		data     []int
+		pop      func(d *Deque[int]) (int, error)
+		wantVal  int
+		wantData []int
+		wantErr  error
+	}{
+		{
+			name:     "pop front empty",
+			data:     []int{},
+			pop:      (*Deque[int]).PopFront,
+			wantData: []int{},
+			wantErr:  ErrEmptyQueue,
+		},
+		{
+			name:     "pop back empty",
+			data:     []int{},
+			pop:      (*Deque[int]).PopBack,
+			wantData: []int{},
+			wantErr:  ErrEmptyQueue,
+		},
+		{
+			name:     "pop front",
+			data:     []int{1, 2, 3},
+			pop:      (*Deque[int]).PopFront,
+			wantVal:  1,
+			wantData: []int{2, 3},
+		},
+		{
+			name:     "pop back",
+			data:     []int{1, 2, 3},
+			pop:      (*Deque[int]).PopBack,
+			wantVal:  3,
+			wantData: []int{1, 2},
+		},
+		{
+			name:     "pop front single",
+			data:     []int{1},
+			pop:      (*Deque[int]).PopFront,
+			wantVal:  1,
+			wantData: []int{},
+		},
+		{
+			name:     "pop back single",
+			data:     []int{1},
+			pop:      (*Deque[int]).PopBack,
+			wantVal:  1,
+			wantData: []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDeque[int]()
+			for _, val := range tc.data {
+				d.PushBack(val)
+			}
+			val, err := tc.pop(d)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantVal, val)
+			assert.Equal(t, tc.wantData, d.asSlice())
+			assert.Equal(t, len(tc.wantData), d.Len())
+		})
+	}
+}
+
+func TestDeque_Concurrent(t *testing.T) {
+	t.Parallel()
+	d := NewDeque[int]()
+	const goroutines, perGoroutine = 10, 100
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				if j%2 == 0 {
+					d.PushFront(i*perGoroutine + j)
+				} else {
+					d.PushBack(i*perGoroutine + j)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, goroutines*perGoroutine, d.Len())
+
+	resultChan := make(chan int, goroutines*perGoroutine)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				pop := d.PopFront
+				if j%2 == 0 {
+					pop = d.PopBack
+				}
+				val, err := pop()
+				assert.NoError(t, err)
+				resultChan <- val
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(resultChan)
+	resultSet := make(map[int]struct{}, goroutines*perGoroutine)
+	for val := range resultChan {
+		resultSet[val] = struct{}{}
+	}
+	assert.Equal(t, goroutines*perGoroutine, len(resultSet))
+	assert.Equal(t, 0, d.Len())
+}
+
+func (d *Deque[T]) asSlice() []T {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	res := make([]T, 0, d.l.Len())
+	for cur := d.l.Front(); cur != nil; cur = d.l.Next(cur) {
+		res = append(res, cur.Val)
+	}
+	return res
+}
+
+func ExampleNewDeque() {
+	d := NewDeque[int]()
+	d.PushBack(2)
+	d.PushFront(1)
+	d.PushBack(3)
+	front, _ := d.PopFront()
+	back, _ := d.PopBack()
+	fmt.Println(front, back)
+	// Output:
+	// 1 3
+}
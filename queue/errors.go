@@ -0,0 +1,32 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"errors"
+
+	"github.com/go-generic/internal/queue"
+)
+
+var (
+	// ErrOutOfCapacity 有界队列已满
+	ErrOutOfCapacity = queue.ErrOutOfCapacity
+	// ErrEmptyQueue 队列为空
+	ErrEmptyQueue = queue.ErrEmptyQueue
+	// ErrNoReadyElement 延时队列中没有已经到期的元素
+	ErrNoReadyElement = errors.New("queue: 没有已到期的元素")
+	// ErrEnqueueTimeout 在指定的时间内没有入队成功
+	ErrEnqueueTimeout = errors.New("queue: 入队超时")
+)
@@ -0,0 +1,98 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"github.com/go-generic"
+	"github.com/go-generic/internal/queue"
+)
+
+// 检查FIFOPriorityQueue是否实现了Queue接口
+var (
+	_ Queue[any] = &FIFOPriorityQueue[any]{}
+)
+
+// FIFOPriorityQueue 公平的优先队列，非并发安全
+// 和 PriorityQueue 一样，compare 返回值小于 0 的元素优先出队；
+// 不同的是，compare 认为相等的元素，会按照入队的顺序出队
+// 内部会给每个元素附加一个单调递增的序号，在 compare 返回 0 的时候用序号来决定先后
+type FIFOPriorityQueue[T any] struct {
+	q   *queue.PriorityQueue[fifoElem[T]]
+	seq uint64
+}
+
+// NewPriorityQueueFIFO 创建公平的优先队列 capacity <= 0 时，为无界队列，否则有有界队列
+func NewPriorityQueueFIFO[T any](capacity int, compare generic.Comparator[T]) *FIFOPriorityQueue[T] {
+	return &FIFOPriorityQueue[T]{
+		q: queue.NewPriorityQueue[fifoElem[T]](capacity, func(src fifoElem[T], dst fifoElem[T]) int {
+			if res := compare(src.val, dst.val); res != 0 {
+				return res
+			}
+			// 优先级相同，先入队的先出队
+			if src.seq < dst.seq {
+				return -1
+			}
+			if src.seq > dst.seq {
+				return 1
+			}
+			return 0
+		}),
+	}
+}
+
+// Enqueue 新元素入队，有界队列已满的时候返回 ErrOutOfCapacity
+func (f *FIFOPriorityQueue[T]) Enqueue(t T) error {
+	err := f.q.Enqueue(fifoElem[T]{val: t, seq: f.seq})
+	if err != nil {
+		return err
+	}
+	f.seq++
+	return nil
+}
+
+// Dequeue 出队，返回优先级最高的元素，优先级相同的时候返回最早入队的元素
+// 队列为空的时候返回 ErrEmptyQueue
+func (f *FIFOPriorityQueue[T]) Dequeue() (T, error) {
+	ele, err := f.q.Dequeue()
+	return ele.val, err
+}
+
+// Peek 返回下一个出队的元素，而不将其从队列中移除
+// 队列为空的时候返回 ErrEmptyQueue
+func (f *FIFOPriorityQueue[T]) Peek() (T, error) {
+	ele, err := f.q.Peek()
+	return ele.val, err
+}
+
+// Len 返回队列中的元素个数
+func (f *FIFOPriorityQueue[T]) Len() int {
+	return f.q.Len()
+}
+
+// Cap 无界队列返回0，有界队列返回创建队列时设置的值
+func (f *FIFOPriorityQueue[T]) Cap() int {
+	return f.q.Cap()
+}
+
+// IsBoundless 判断是否是无界队列
+func (f *FIFOPriorityQueue[T]) IsBoundless() bool {
+	return f.q.IsBoundless()
+}
+
+type fifoElem[T any] struct {
+	val T
+	// 入队的序号，用于在优先级相同的时候保证 FIFO
+	seq uint64
+}
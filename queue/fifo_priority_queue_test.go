@@ -0,0 +1,150 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-generic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFIFOPriorityQueue(t *testing.T) {
+	compare := func(src fifoTask, dst fifoTask) int {
+		return generic.ComparatorRealNumber(src.priority, dst.priority)
+	}
+	testCases := []struct {
+		name     string
+		capacity int
+		data     []fifoTask
+		wantErr  error
+		wantIds  []int
+	}{
+		{
+			name:    "empty",
+			wantIds: []int{},
+		},
+		{
+			name: "same priority",
+			data: []fifoTask{
+				{id: 1, priority: 1}, {id: 2, priority: 1}, {id: 3, priority: 1},
+				{id: 4, priority: 1}, {id: 5, priority: 1}, {id: 6, priority: 1},
+				{id: 7, priority: 1}, {id: 8, priority: 1}, {id: 9, priority: 1},
+			},
+			wantIds: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name: "mixed priority",
+			data: []fifoTask{
+				{id: 1, priority: 2}, {id: 2, priority: 1}, {id: 3, priority: 2},
+				{id: 4, priority: 3}, {id: 5, priority: 1}, {id: 6, priority: 2},
+				{id: 7, priority: 1}, {id: 8, priority: 3},
+			},
+			wantIds: []int{2, 5, 7, 1, 3, 6, 4, 8},
+		},
+		{
+			name:     "out of capacity",
+			capacity: 2,
+			data: []fifoTask{
+				{id: 1, priority: 1}, {id: 2, priority: 1}, {id: 3, priority: 1},
+			},
+			wantErr: ErrOutOfCapacity,
+			wantIds: []int{1, 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewPriorityQueueFIFO[fifoTask](tc.capacity, compare)
+			assert.Equal(t, tc.capacity, q.Cap())
+			assert.Equal(t, tc.capacity <= 0, q.IsBoundless())
+			var err error
+			for _, task := range tc.data {
+				if err = q.Enqueue(task); err != nil {
+					break
+				}
+			}
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, len(tc.wantIds), q.Len())
+			ids := make([]int, 0, q.Len())
+			for q.Len() > 0 {
+				head, err := q.Peek()
+				require.NoError(t, err)
+				task, err := q.Dequeue()
+				require.NoError(t, err)
+				assert.Equal(t, head, task)
+				ids = append(ids, task.id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+			_, err = q.Dequeue()
+			assert.Equal(t, ErrEmptyQueue, err)
+			_, err = q.Peek()
+			assert.Equal(t, ErrEmptyQueue, err)
+		})
+	}
+}
+
+func TestFIFOPriorityQueue_Interleaved(t *testing.T) {
+	// 入队出队交替进行，优先级相同的元素依旧按照入队顺序出队
+	q := NewPriorityQueueFIFO[fifoTask](0, func(src fifoTask, dst fifoTask) int {
+		return generic.ComparatorRealNumber(src.priority, dst.priority)
+	})
+	ids := make([]int, 0, 100)
+	id := 0
+	for round := 0; round < 10; round++ {
+		for i := 0; i < 10; i++ {
+			require.NoError(t, q.Enqueue(fifoTask{id: id, priority: 1}))
+			id++
+		}
+		for i := 0; i < 5; i++ {
+			task, err := q.Dequeue()
+			require.NoError(t, err)
+			ids = append(ids, task.id)
+		}
+	}
+	for q.Len() > 0 {
+		task, err := q.Dequeue()
+		require.NoError(t, err)
+		ids = append(ids, task.id)
+	}
+	for i, id := range ids {
+		assert.Equal(t, i, id)
+	}
+}
+
+type fifoTask struct {
+	id       int
+	priority int
+}
+
+func ExampleNewPriorityQueueFIFO() {
+	q := NewPriorityQueueFIFO[fifoTask](0, func(src fifoTask, dst fifoTask) int {
+		return generic.ComparatorRealNumber(src.priority, dst.priority)
+	})
+	_ = q.Enqueue(fifoTask{id: 1, priority: 2})
+	_ = q.Enqueue(fifoTask{id: 2, priority: 1})
+	_ = q.Enqueue(fifoTask{id: 3, priority: 2})
+	_ = q.Enqueue(fifoTask{id: 4, priority: 1})
+	for q.Len() > 0 {
+		task, _ := q.Dequeue()
+		fmt.Println(task.id)
+	}
+	// Output:
+	// 2
+	// 4
+	// 1
+	// 3
+}
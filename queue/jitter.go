@@ -0,0 +1,71 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"math/rand"
+	"time"
+)
+
+// JitterDelayable 给 Delayable 加上一个随机的抖动，避免大量到期时间相同的元素同时出队
+// 抖动是在创建的时候确定的，之后每次调用 Delay() 都会加上同一个抖动，
+// 所以元素在延时队列中的相对顺序是稳定的，不会破坏堆的性质
+// 如果 Val 实现了 Deadliner，那么延时队列依旧会根据 WithClock 注入的时钟计算加上抖动之后的延时
+type JitterDelayable[T Delayable] struct {
+	// Val 原始的元素
+	Val    T
+	jitter time.Duration
+}
+
+// NewJitterDelayable 创建一个 JitterDelayable，抖动是 [0, maxJitter) 之间的随机值，也就是说只会推迟而不会提前到期
+// maxJitter <= 0 的时候不会加上任何抖动
+func NewJitterDelayable[T Delayable](val T, maxJitter time.Duration) JitterDelayable[T] {
+	var jitter time.Duration
+	if maxJitter > 0 {
+		jitter = time.Duration(rand.Int63n(int64(maxJitter)))
+	}
+	return JitterDelayable[T]{
+		Val:    val,
+		jitter: jitter,
+	}
+}
+
+// Delay 返回原始元素的延时加上抖动
+func (j JitterDelayable[T]) Delay() time.Duration {
+	return j.Val.Delay() + j.jitter
+}
+
+// Jitter 返回创建时确定的抖动
+func (j JitterDelayable[T]) Jitter() time.Duration {
+	return j.jitter
+}
+
+// Deadline 返回加上抖动之后的到期时间
+// Val 实现了 Deadliner 的时候是 Val.Deadline() 加上抖动，否则根据真实时间和 Delay() 计算
+func (j JitterDelayable[T]) Deadline() time.Time {
+	if dl, ok := j.deadline(); ok {
+		return dl
+	}
+	return time.Now().Add(j.Delay())
+}
+
+// deadline 只有 Val 实现了 Deadliner 的时候才返回到期时间，
+// 这样延时队列不会把没有实现 Deadliner 的 Val 当成根据时钟到期的元素
+func (j JitterDelayable[T]) deadline() (time.Time, bool) {
+	if dl, ok := any(j.Val).(Deadliner); ok {
+		return dl.Deadline().Add(j.jitter), true
+	}
+	return time.Time{}, false
+}
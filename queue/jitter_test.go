@@ -0,0 +1,139 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJitterDelayable(t *testing.T) {
+	t.Parallel()
+	deadline := time.Now().Add(time.Hour)
+	testCases := []struct {
+		name      string
+		maxJitter time.Duration
+	}{
+		{
+			name:      "negative",
+			maxJitter: -time.Second,
+		},
+		{
+			name:      "zero",
+			maxJitter: 0,
+		},
+		{
+			name:      "one nanosecond",
+			maxJitter: 1,
+		},
+		{
+			name:      "one second",
+			maxJitter: time.Second,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				ele := NewJitterDelayable(delayElem{val: i, deadline: deadline}, tc.maxJitter)
+				assert.Equal(t, i, ele.Val.val)
+				jitter := ele.Jitter()
+				assert.GreaterOrEqual(t, jitter, time.Duration(0))
+				assert.Less(t, jitter, max(tc.maxJitter, 1))
+				// 抖动是固定的，Delay 只受时间流逝的影响
+				assert.InDelta(t, float64(ele.Val.Delay()+jitter), float64(ele.Delay()), float64(time.Millisecond))
+			}
+		})
+	}
+}
+
+func TestJitterDelayable_Spread(t *testing.T) {
+	t.Parallel()
+	deadline := time.Now().Add(time.Hour)
+	jitters := make(map[time.Duration]struct{}, 100)
+	for i := 0; i < 100; i++ {
+		ele := NewJitterDelayable(delayElem{deadline: deadline}, time.Second)
+		jitters[ele.Jitter()] = struct{}{}
+	}
+	// 到期时间相同的元素会被分散开
+	assert.Greater(t, len(jitters), 1)
+}
+
+func TestJitterDelayable_DelayQueue(t *testing.T) {
+	t.Parallel()
+	q := NewDelayQueue[JitterDelayable[delayElem]](10)
+	deadline := time.Now().Add(50 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, q.Enqueue(context.Background(),
+			NewJitterDelayable(delayElem{val: i, deadline: deadline}, 50*time.Millisecond)))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var last time.Duration
+	for i := 0; i < 5; i++ {
+		ele, err := q.Dequeue(ctx)
+		require.NoError(t, err)
+		// 按照加上抖动之后的到期时间出队
+		assert.GreaterOrEqual(t, ele.Jitter(), last)
+		last = ele.Jitter()
+	}
+}
+
+func TestJitterDelayable_Deadline(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ele := NewJitterDelayable(deadlineElem{deadline: start.Add(time.Second), val: 1}, time.Second)
+	assert.Equal(t, start.Add(time.Second).Add(ele.Jitter()), ele.Deadline())
+
+	// Val 没有实现 Deadliner 的时候根据真实时间计算
+	plain := NewJitterDelayable(delayElem{deadline: time.Now().Add(time.Hour), val: 1}, time.Second)
+	assert.WithinDuration(t, time.Now().Add(time.Hour).Add(plain.Jitter()), plain.Deadline(), time.Second)
+	_, ok := deadlineOf(plain)
+	assert.False(t, ok)
+}
+
+func TestJitterDelayable_WithClock(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	q := NewDelayQueue[JitterDelayable[deadlineElem]](10, WithClock[JitterDelayable[deadlineElem]](clock))
+	ele := NewJitterDelayable(deadlineElem{deadline: start.Add(time.Second), val: 1}, time.Second)
+	require.NoError(t, q.Enqueue(context.Background(), ele))
+
+	// 延时根据注入的时钟计算，并且加上了抖动
+	delay, err := q.PeekDelay()
+	require.NoError(t, err)
+	assert.Equal(t, time.Second+ele.Jitter(), delay)
+
+	clock.Advance(time.Second + ele.Jitter() - time.Nanosecond)
+	_, err = q.TryDequeue(context.Background())
+	assert.Equal(t, ErrNoReadyElement, err)
+
+	clock.Advance(time.Nanosecond)
+	res, err := q.TryDequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Val.val)
+}
+
+func ExampleNewJitterDelayable() {
+	ele := NewJitterDelayable(delayElem{val: 1, deadline: time.Now()}, time.Second)
+	fmt.Println(ele.Val.val, ele.Jitter() < time.Second)
+	// Output:
+	// 1 true
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"github.com/go-generic"
+	"github.com/go-generic/internal/queue"
+	"github.com/go-generic/slice"
+)
+
+// 检查PriorityQueue是否实现了Queue接口
+var (
+	_ Queue[any] = &PriorityQueue[any]{}
+)
+
+// PriorityQueue 基于小顶堆的优先队列，非并发安全
+// 比较函数的语义和 DelayQueue、ConcurrentPriorityQueue 保持一致，compare 返回值小于 0 的元素优先出队
+// 当capacity <= 0时，为无界队列，切片容量会动态扩缩容
+// 当capacity > 0 时，为有界队列，初始化后就固定容量，不会扩缩容
+// 如果需要在多个 goroutine 之间共享，请使用 ConcurrentPriorityQueue
+type PriorityQueue[T any] struct {
+	queue.PriorityQueue[T]
+}
+
+// PriorityQueueOption 优先队列的可选配置
+type PriorityQueueOption[T any] queue.Option[T]
+
+// WithShrinkPolicy 设置无界优先队列出队之后的缩容策略，默认是 slice.DefaultShrinkPolicy
+// 传入 slice.NoShrink 可以禁止缩容，适合元素个数频繁大幅波动的场景。对有界队列没有影响
+func WithShrinkPolicy[T any](policy slice.ShrinkPolicy) PriorityQueueOption[T] {
+	return PriorityQueueOption[T](queue.WithShrinkPolicy[T](policy))
+}
+
+// WithInitialCap 预先为无界优先队列分配能够容纳 initialCap 个元素的空间，默认能够容纳 63 个元素
+// 适合批量入队之前预先知道元素个数的场景，例如配合 EnqueueAll 使用，可以避免多次扩容
+// 对有界队列没有影响
+func WithInitialCap[T any](initialCap int) PriorityQueueOption[T] {
+	return PriorityQueueOption[T](queue.WithInitialCap[T](initialCap))
+}
+
+// NewPriorityQueue 创建优先队列 capacity <= 0 时，为无界队列，否则有有界队列
+func NewPriorityQueue[T any](capacity int, compare generic.Comparator[T], opts ...PriorityQueueOption[T]) *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		PriorityQueue: *queue.NewPriorityQueue[T](capacity, compare, toOptions(opts)...),
+	}
+}
+
+// NewPriorityQueueFromSlice 使用 data 中的元素创建优先队列，时间复杂度 O(n)
+// data 会被复制，调用者可以继续使用 data 而不会影响队列
+// 如果是有界队列并且 data 的长度超出了 capacity，会 panic
+func NewPriorityQueueFromSlice[T any](capacity int, data []T, compare generic.Comparator[T], opts ...PriorityQueueOption[T]) *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		PriorityQueue: *queue.NewPriorityQueueFromSlice[T](capacity, data, compare, toOptions(opts)...),
+	}
+}
+
+// Clone 复制一个优先队列，两者使用相同的比较函数和容量
+// 副本拥有独立的底层切片，修改其中一个不会影响另外一个
+func (p *PriorityQueue[T]) Clone() *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		PriorityQueue: *p.PriorityQueue.Clone(),
+	}
+}
+
+// Merge 将 other 中的所有元素合并到当前队列中，other 本身保持不变，时间复杂度 O(n)
+// 对于有界队列，如果合并之后会超出容量，返回 ErrOutOfCapacity，并且队列保持不变
+// other 为 nil 的时候当作空队列处理，直接返回 nil
+// 注意：两个队列必须使用相同的比较函数，否则合并之后的出队顺序是未定义的
+func (p *PriorityQueue[T]) Merge(other *PriorityQueue[T]) error {
+	if other == nil {
+		return nil
+	}
+	return p.PriorityQueue.Merge(&other.PriorityQueue)
+}
+
+func toOptions[T any](opts []PriorityQueueOption[T]) []queue.Option[T] {
+	res := make([]queue.Option[T], len(opts))
+	for i, opt := range opts {
+		res[i] = queue.Option[T](opt)
+	}
+	return res
+}
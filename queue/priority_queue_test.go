@@ -0,0 +1,224 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-generic"
+	"github.com/go-generic/slice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPriorityQueue(t *testing.T) {
+	testCases := []struct {
+		name      string
+		q         *PriorityQueue[int]
+		capacity  int
+		boundless bool
+		data      []int
+		expect    []int
+		wantErr   error
+	}{
+		{
+			name:      "无边界",
+			q:         NewPriorityQueue(0, generic.ComparatorRealNumber[int]),
+			capacity:  0,
+			boundless: true,
+			data:      []int{6, 5, 4, 3, 2, 1},
+			expect:    []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:     "有边界",
+			q:        NewPriorityQueue(6, generic.ComparatorRealNumber[int]),
+			capacity: 6,
+			data:     []int{6, 5, 4, 3, 2, 1},
+			expect:   []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:      "大顶堆",
+			q:         NewPriorityQueue(0, generic.Reverse(generic.ComparatorRealNumber[int])),
+			capacity:  0,
+			boundless: true,
+			data:      []int{1, 3, 2, 6, 5, 4},
+			expect:    []int{6, 5, 4, 3, 2, 1},
+		},
+		{
+			name:     "有边界，超出容量",
+			q:        NewPriorityQueue(5, generic.ComparatorRealNumber[int]),
+			capacity: 5,
+			data:     []int{6, 5, 4, 3, 2, 1},
+			wantErr:  ErrOutOfCapacity,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, 0, tc.q.Len())
+			assert.Equal(t, tc.capacity, tc.q.Cap())
+			assert.Equal(t, tc.boundless, tc.q.IsBoundless())
+			var err error
+			for _, d := range tc.data {
+				if err = tc.q.Enqueue(d); err != nil {
+					break
+				}
+			}
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, len(tc.data), tc.q.Len())
+			res := make([]int, 0, len(tc.data))
+			for tc.q.Len() > 0 {
+				head, err := tc.q.Peek()
+				require.NoError(t, err)
+				el, err := tc.q.Dequeue()
+				require.NoError(t, err)
+				assert.Equal(t, head, el)
+				res = append(res, el)
+			}
+			assert.Equal(t, tc.expect, res)
+			_, err = tc.q.Dequeue()
+			assert.Equal(t, ErrEmptyQueue, err)
+			_, err = tc.q.Peek()
+			assert.Equal(t, ErrEmptyQueue, err)
+		})
+	}
+}
+
+func TestNewPriorityQueueFromSlice(t *testing.T) {
+	data := []int{3, 1, 2}
+	q := NewPriorityQueueFromSlice[int](0, data, generic.ComparatorRealNumber[int])
+	assert.Equal(t, 3, q.Len())
+	val, err := q.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	// 原始切片不受影响
+	assert.Equal(t, []int{3, 1, 2}, data)
+	assert.Panics(t, func() {
+		NewPriorityQueueFromSlice[int](2, data, generic.ComparatorRealNumber[int])
+	})
+}
+
+func TestWithShrinkPolicy(t *testing.T) {
+	// 缩容的细节由 internal/queue 中的测试覆盖，这里只确认配置之后队列依旧可以正常工作
+	q := NewPriorityQueue[int](0, generic.ComparatorRealNumber[int],
+		WithShrinkPolicy[int](slice.NoShrink))
+	for i := 2000; i > 0; i-- {
+		require.NoError(t, q.Enqueue(i))
+	}
+	for i := 1; i <= 2000; i++ {
+		val, err := q.Dequeue()
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+}
+
+func TestWithInitialCap(t *testing.T) {
+	// 预分配的细节由 internal/queue 中的测试覆盖，这里只确认配置之后队列依旧可以正常工作
+	data := []int{5, 3, 4, 1, 2}
+	q := NewPriorityQueue[int](0, generic.ComparatorRealNumber[int],
+		WithInitialCap[int](len(data)))
+	require.NoError(t, q.EnqueueAll(data))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, q.AsSortedSlice())
+}
+
+func TestPriorityQueue_Clone(t *testing.T) {
+	q := NewPriorityQueue[int](0, generic.ComparatorRealNumber[int])
+	for _, el := range []int{3, 1, 2} {
+		require.NoError(t, q.Enqueue(el))
+	}
+	cp := q.Clone()
+	val, err := cp.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, 2, cp.Len())
+	// 原来的队列不受影响
+	assert.Equal(t, 3, q.Len())
+	val, err = q.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+}
+
+func TestPriorityQueue_Merge(t *testing.T) {
+	q := NewPriorityQueueFromSlice[int](0, []int{5, 1, 3}, generic.ComparatorRealNumber[int])
+	other := NewPriorityQueueFromSlice[int](0, []int{4, 2}, generic.ComparatorRealNumber[int])
+	require.NoError(t, q.Merge(other))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, q.AsSortedSlice())
+	assert.Equal(t, []int{2, 4}, other.AsSortedSlice())
+
+	bounded := NewPriorityQueueFromSlice[int](3, []int{1, 2}, generic.ComparatorRealNumber[int])
+	assert.Equal(t, errOutOfCapacity, bounded.Merge(other))
+	assert.Equal(t, []int{1, 2}, bounded.AsSortedSlice())
+
+	// nil 当作空队列处理
+	require.NoError(t, bounded.Merge(nil))
+	assert.Equal(t, []int{1, 2}, bounded.AsSortedSlice())
+}
+
+func ExampleNewPriorityQueue() {
+	q := NewPriorityQueue[int](0, generic.ComparatorRealNumber[int])
+	_ = q.Enqueue(3)
+	_ = q.Enqueue(1)
+	_ = q.Enqueue(2)
+	var vals []int
+	for q.Len() > 0 {
+		val, _ := q.Dequeue()
+		vals = append(vals, val)
+	}
+	fmt.Println(vals)
+	// Output:
+	// [1 2 3]
+}
+
+func ExamplePriorityQueue_Update() {
+	q := NewPriorityQueueFromSlice[int](0, []int{5, 3, 8}, generic.ComparatorRealNumber[int])
+	// 将 8 的优先级提高到最前面
+	ok := q.Update(func(val int) bool {
+		return val == 8
+	}, 1)
+	fmt.Println(ok, q.AsSortedSlice())
+	// Output:
+	// true [1 3 5]
+}
+
+func ExamplePriorityQueue_EnqueueOrReplace() {
+	// 使用大顶堆保留最小的 3 个元素
+	q := NewPriorityQueue[int](3, generic.Reverse(generic.ComparatorRealNumber[int]))
+	for _, val := range []int{5, 1, 9, 3, 7} {
+		_, _, _ = q.EnqueueOrReplace(val)
+	}
+	fmt.Println(q.AsSortedSlice())
+	// Output:
+	// [5 3 1]
+}
+
+func ExamplePriorityQueue_String() {
+	q := NewPriorityQueueFromSlice[int](0, []int{3, 1, 2}, generic.ComparatorRealNumber[int])
+	// 按照堆的存储顺序输出
+	fmt.Println(q)
+	// Output:
+	// [1 3 2]
+}
+
+func ExamplePriorityQueue_DequeueLessThan() {
+	q := NewPriorityQueueFromSlice[int](0, []int{5, 1, 4, 2, 3}, generic.ComparatorRealNumber[int])
+	fmt.Println(q.DequeueLessThan(3))
+	fmt.Println(q.AsSortedSlice())
+	// Output:
+	// [1 2]
+	// [3 4 5]
+}
@@ -0,0 +1,155 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// Scheduler 基于 DelayQueue 的定时调度器
+// 元素到期之后，后台协程会调用 fn 来处理它
+// fn 是在同一个后台协程里面串行调用的，如果 fn 执行耗时较长，会推迟后续元素的处理，
+// 这种情况下用户应该在 fn 里面自己开启协程
+//
+// 停止策略：Stop 或者 Start 传入的 ctx 被取消之后，后台协程会在当前 fn 执行完毕之后退出，
+// 还没有到期的元素会被留在队列里面，不会被处理也不会被丢弃。再次调用 Start 会继续处理这些元素
+type Scheduler[T Delayable] struct {
+	q  *DelayQueue[T]
+	fn func(T)
+
+	mutex  sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+	// loops 还没有退出的后台协程的 goroutine id，用于识别在 fn 里面调用的 Stop
+	loops map[uint64]struct{}
+}
+
+// NewScheduler 创建一个调度器，capacity 是内部延时队列的容量
+// capacity <= 0 时，内部延时队列为无界队列
+func NewScheduler[T Delayable](capacity int, fn func(T)) *Scheduler[T] {
+	return &Scheduler[T]{
+		q:  NewDelayQueue[T](capacity),
+		fn: fn,
+	}
+}
+
+// Schedule 提交一个元素，元素到期之后会被 fn 处理
+// 内部延时队列已满的时候会阻塞，直到有空闲位置或者 ctx 过期
+// 在调用 Start 之前也可以提交元素
+func (s *Scheduler[T]) Schedule(ctx context.Context, t T) error {
+	return s.q.Enqueue(ctx, t)
+}
+
+// Start 启动后台协程，非阻塞
+// 后台协程在 ctx 被取消或者调用 Stop 之后退出，退出之后可以再次调用 Start
+// 如果调度器已经启动，那么什么也不会发生
+// 如果上一个后台协程还没有退出（例如 Stop 是在 fn 里面调用的），
+// 新的后台协程会等它退出之后再开始处理元素，保证 fn 始终是串行调用的
+func (s *Scheduler[T]) Start(ctx context.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	prev := s.done
+	done := make(chan struct{})
+	s.cancel = cancel
+	s.done = done
+	go s.loop(ctx, cancel, prev, done)
+}
+
+func (s *Scheduler[T]) loop(ctx context.Context, cancel context.CancelFunc,
+	prev <-chan struct{}, done chan struct{}) {
+	defer func() {
+		cancel()
+		s.mutex.Lock()
+		// ctx 被取消导致的退出，也要清理状态，否则无法再次启动
+		if s.done == done {
+			s.cancel = nil
+			s.done = nil
+		}
+		s.mutex.Unlock()
+		close(done)
+	}()
+	id := goroutineID()
+	s.mutex.Lock()
+	if s.loops == nil {
+		s.loops = make(map[uint64]struct{}, 1)
+	}
+	s.loops[id] = struct{}{}
+	s.mutex.Unlock()
+	defer func() {
+		s.mutex.Lock()
+		delete(s.loops, id)
+		s.mutex.Unlock()
+	}()
+	if prev != nil {
+		select {
+		case <-prev:
+		case <-ctx.Done():
+			return
+		}
+	}
+	for {
+		t, err := s.q.Dequeue(ctx)
+		if err != nil {
+			// 只可能是 ctx 被取消了
+			return
+		}
+		s.fn(t)
+	}
+}
+
+// Stop 停止后台协程，并且等待它退出
+// 如果正在执行 fn，那么会等待 fn 返回。未到期的元素会留在队列里面
+// 在 fn 里面调用 Stop 的时候无法等待自己返回，所以只会通知后台协程退出，
+// 后台协程会在这一次 fn 返回之后退出，不会再处理新的元素
+// 如果调度器没有启动，那么什么也不会发生
+func (s *Scheduler[T]) Stop() {
+	s.mutex.Lock()
+	cancel, done := s.cancel, s.done
+	// 保留 s.done，再次调用 Start 的时候用来等待这个后台协程退出
+	s.cancel = nil
+	_, inLoop := s.loops[goroutineID()]
+	s.mutex.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	if !inLoop {
+		<-done
+	}
+}
+
+// Len 返回还没有被处理的元素个数
+func (s *Scheduler[T]) Len() int {
+	return s.q.Len()
+}
+
+// goroutineID 返回当前 goroutine 的 id
+// Go 没有直接暴露 goroutine id，这里从 runtime.Stack 的第一行 "goroutine 123 [running]:" 中解析
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	b = b[:bytes.IndexByte(b, ' ')]
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,234 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	resultChan := make(chan int, 3)
+	s := NewScheduler[delayElem](0, func(ele delayElem) {
+		resultChan <- ele.val
+	})
+	ctx := context.Background()
+	// 启动之前提交
+	require.NoError(t, s.Schedule(ctx, delayElem{
+		deadline: now.Add(time.Millisecond * 300),
+		val:      3,
+	}))
+	s.Start(ctx)
+	// 重复启动
+	s.Start(ctx)
+	require.NoError(t, s.Schedule(ctx, delayElem{
+		deadline: now.Add(time.Millisecond * 100),
+		val:      1,
+	}))
+	require.NoError(t, s.Schedule(ctx, delayElem{
+		deadline: now.Add(time.Millisecond * 200),
+		val:      2,
+	}))
+	vals := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		select {
+		case val := <-resultChan:
+			vals = append(vals, val)
+		case <-time.After(time.Second):
+			t.Fatal("等待调度超时")
+		}
+	}
+	assert.Equal(t, []int{1, 2, 3}, vals)
+	s.Stop()
+	// 重复停止
+	s.Stop()
+}
+
+func TestScheduler_Stop(t *testing.T) {
+	t.Parallel()
+	called := make(chan int, 1)
+	s := NewScheduler[delayElem](0, func(ele delayElem) {
+		called <- ele.val
+	})
+	ctx := context.Background()
+	// 没有启动的时候停止
+	s.Stop()
+	require.NoError(t, s.Schedule(ctx, delayElem{
+		deadline: time.Now().Add(time.Millisecond * 200),
+		val:      1,
+	}))
+	s.Start(ctx)
+	s.Stop()
+	// 停止之后，未到期的元素留在队列里面，不会被处理
+	time.Sleep(time.Millisecond * 300)
+	assert.Equal(t, 1, s.Len())
+	assert.Empty(t, called)
+
+	// 再次启动，继续处理留下来的元素
+	s.Start(ctx)
+	defer s.Stop()
+	select {
+	case val := <-called:
+		assert.Equal(t, 1, val)
+	case <-time.After(time.Second):
+		t.Fatal("等待调度超时")
+	}
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestScheduler_ContextCanceled(t *testing.T) {
+	t.Parallel()
+	s := NewScheduler[delayElem](0, func(ele delayElem) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+	done := s.done
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("后台协程没有退出")
+	}
+	// ctx 被取消之后，Stop 依旧可以正常返回
+	s.Stop()
+}
+
+func TestScheduler_RestartAfterContextCanceled(t *testing.T) {
+	t.Parallel()
+	called := make(chan int, 1)
+	s := NewScheduler[delayElem](0, func(ele delayElem) {
+		called <- ele.val
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+	s.mutex.Lock()
+	done := s.done
+	s.mutex.Unlock()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("后台协程没有退出")
+	}
+
+	// ctx 被取消之后，可以再次启动
+	s.Start(context.Background())
+	defer s.Stop()
+	require.NoError(t, s.Schedule(context.Background(), delayElem{
+		deadline: time.Now().Add(time.Millisecond * 10),
+		val:      1,
+	}))
+	select {
+	case val := <-called:
+		assert.Equal(t, 1, val)
+	case <-time.After(time.Second):
+		t.Fatal("等待调度超时")
+	}
+}
+
+func TestScheduler_StopInFn(t *testing.T) {
+	t.Parallel()
+	var s *Scheduler[delayElem]
+	called := make(chan int, 2)
+	s = NewScheduler[delayElem](0, func(ele delayElem) {
+		// 在 fn 里面调用 Stop 不会死锁
+		s.Stop()
+		called <- ele.val
+	})
+	ctx := context.Background()
+	now := time.Now()
+	require.NoError(t, s.Schedule(ctx, delayElem{deadline: now, val: 1}))
+	require.NoError(t, s.Schedule(ctx, delayElem{deadline: now, val: 2}))
+	s.Start(ctx)
+	select {
+	case val := <-called:
+		assert.Equal(t, 1, val)
+	case <-time.After(time.Second):
+		t.Fatal("在 fn 里面调用 Stop 导致死锁")
+	}
+	// 停止之后不会再处理剩下的元素
+	time.Sleep(time.Millisecond * 100)
+	assert.Empty(t, called)
+	assert.Equal(t, 1, s.Len())
+
+	// 再次启动，继续处理剩下的元素
+	s.Start(ctx)
+	select {
+	case val := <-called:
+		assert.Equal(t, 2, val)
+	case <-time.After(time.Second):
+		t.Fatal("等待调度超时")
+	}
+}
+
+func TestScheduler_StopDuringFn(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{})
+	var returned atomic.Bool
+	s := NewScheduler[delayElem](0, func(ele delayElem) {
+		close(started)
+		time.Sleep(time.Millisecond * 200)
+		returned.Store(true)
+	})
+	ctx := context.Background()
+	require.NoError(t, s.Schedule(ctx, delayElem{deadline: time.Now(), val: 1}))
+	s.Start(ctx)
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("等待调度超时")
+	}
+	// 在 fn 外面调用 Stop，会等待正在执行的 fn 返回
+	s.Stop()
+	assert.True(t, returned.Load())
+}
+
+func TestGoroutineID(t *testing.T) {
+	t.Parallel()
+	id := goroutineID()
+	assert.NotZero(t, id)
+	assert.Equal(t, id, goroutineID())
+	ch := make(chan uint64)
+	go func() {
+		ch <- goroutineID()
+	}()
+	other := <-ch
+	assert.NotZero(t, other)
+	assert.NotEqual(t, id, other)
+}
+
+func ExampleNewScheduler() {
+	done := make(chan struct{})
+	s := NewScheduler[delayElem](0, func(ele delayElem) {
+		fmt.Println(ele.val)
+		close(done)
+	})
+	s.Start(context.Background())
+	defer s.Stop()
+	_ = s.Schedule(context.Background(), delayElem{
+		deadline: time.Now().Add(time.Millisecond * 100),
+		val:      1,
+	})
+	<-done
+	// Output:
+	// 1
+}
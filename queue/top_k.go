@@ -0,0 +1,61 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"slices"
+
+	"github.com/go-generic"
+	"github.com/go-generic/internal/queue"
+)
+
+// TopK 保留所有元素中最大的 k 个，非并发安全
+// 内部是一个容量为 k 的小顶堆，堆顶是目前保留的最小元素，新元素只有比堆顶大的时候才会替换堆顶
+// 所以每次 Offer 的时间复杂度是 O(logk)，空间复杂度是 O(k)，适合在数据流上统计
+// 如果需要保留最小的 k 个元素，传入 generic.Reverse(compare) 即可
+type TopK[T any] struct {
+	q *queue.PriorityQueue[T]
+}
+
+// NewTopK 创建一个 TopK，compare 的语义和 PriorityQueue 一致
+// k <= 0 的时候会 panic
+func NewTopK[T any](k int, compare generic.Comparator[T]) *TopK[T] {
+	if k <= 0 {
+		panic("queue: TopK 的 k 必须大于 0")
+	}
+	return &TopK[T]{
+		q: queue.NewPriorityQueue[T](k, compare),
+	}
+}
+
+// Offer 提交一个元素，如果它是目前最大的 k 个元素之一，就会被保留下来
+func (t *TopK[T]) Offer(val T) {
+	// 有界队列的 EnqueueOrReplace 不会返回 error
+	_, _, _ = t.q.EnqueueOrReplace(val)
+}
+
+// Result 返回目前保留的元素，从大到小排列
+// 提交的元素不足 k 个的时候，返回所有提交过的元素；没有提交过元素的时候返回一个空切片
+// 每次调用都会返回一个新的切片，不会影响 TopK 本身
+func (t *TopK[T]) Result() []T {
+	res := t.q.AsSortedSlice()
+	slices.Reverse(res)
+	return res
+}
+
+// Len 返回目前保留的元素个数，不会超过 k
+func (t *TopK[T]) Len() int {
+	return t.q.Len()
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopK(t *testing.T) {
+	testCases := []struct {
+		name    string
+		k       int
+		compare generic.Comparator[int]
+		input   []int
+		want    []int
+	}{
+		{
+			name:    "empty",
+			k:       3,
+			compare: generic.ComparatorRealNumber[int],
+			want:    []int{},
+		},
+		{
+			name:    "less than k",
+			k:       3,
+			compare: generic.ComparatorRealNumber[int],
+			input:   []int{2, 5},
+			want:    []int{5, 2},
+		},
+		{
+			name:    "exactly k",
+			k:       3,
+			compare: generic.ComparatorRealNumber[int],
+			input:   []int{2, 5, 1},
+			want:    []int{5, 2, 1},
+		},
+		{
+			name:    "more than k",
+			k:       3,
+			compare: generic.ComparatorRealNumber[int],
+			input:   []int{5, 1, 9, 3, 7, 2, 8},
+			want:    []int{9, 8, 7},
+		},
+		{
+			name:    "duplicate",
+			k:       3,
+			compare: generic.ComparatorRealNumber[int],
+			input:   []int{5, 5, 1, 5, 9},
+			want:    []int{9, 5, 5},
+		},
+		{
+			name:    "k is 1",
+			k:       1,
+			compare: generic.ComparatorRealNumber[int],
+			input:   []int{5, 1, 9, 3},
+			want:    []int{9},
+		},
+		{
+			name:    "smallest k",
+			k:       3,
+			compare: generic.Reverse(generic.ComparatorRealNumber[int]),
+			input:   []int{5, 1, 9, 3, 7, 2, 8},
+			want:    []int{1, 2, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			topK := NewTopK[int](tc.k, tc.compare)
+			for _, val := range tc.input {
+				topK.Offer(val)
+			}
+			assert.Equal(t, len(tc.want), topK.Len())
+			assert.Equal(t, tc.want, topK.Result())
+			// Result 不会影响 TopK 本身
+			assert.Equal(t, tc.want, topK.Result())
+		})
+	}
+
+	assert.Panics(t, func() {
+		NewTopK[int](0, generic.ComparatorRealNumber[int])
+	})
+	assert.Panics(t, func() {
+		NewTopK[int](-1, generic.ComparatorRealNumber[int])
+	})
+}
+
+func ExampleNewTopK() {
+	topK := NewTopK[int](3, generic.ComparatorRealNumber[int])
+	for _, val := range []int{5, 1, 9, 3, 7, 2, 8} {
+		topK.Offer(val)
+	}
+	fmt.Println(topK.Result())
+	// Output:
+	// [9 8 7]
+}
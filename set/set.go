@@ -0,0 +1,77 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package set
+
+// Set 基于 map 实现的集合，非并发安全
+type Set[T comparable] struct {
+	// 使用空结构体作为 value，减少内存消耗
+	m map[T]struct{}
+}
+
+// NewSet 创建一个集合，size 为预估的元素个数
+func NewSet[T comparable](size int) *Set[T] {
+	return &Set[T]{
+		m: make(map[T]struct{}, size),
+	}
+}
+
+// SliceToSet 使用切片中的元素创建一个集合，重复的元素只会保留一个
+// 传入的切片为nil的时候返回一个空集合
+func SliceToSet[T comparable](src []T) *Set[T] {
+	res := NewSet[T](len(src))
+	for _, val := range src {
+		res.Add(val)
+	}
+	return res
+}
+
+// Add 添加元素，元素已经存在的时候什么也不会发生
+func (s *Set[T]) Add(key T) {
+	s.m[key] = struct{}{}
+}
+
+// Remove 删除元素，元素不存在的时候什么也不会发生
+func (s *Set[T]) Remove(key T) {
+	delete(s.m, key)
+}
+
+// Contains 判断元素是否存在
+func (s *Set[T]) Contains(key T) bool {
+	_, ok := s.m[key]
+	return ok
+}
+
+// Len 返回元素个数
+func (s *Set[T]) Len() int {
+	return len(s.m)
+}
+
+// Keys 返回集合中的所有元素
+// 返回值的元素顺序是不定的
+// 集合为空的时候返回一个空切片而不是 nil
+func (s *Set[T]) Keys() []T {
+	res := make([]T, 0, len(s.m))
+	for key := range s.m {
+		res = append(res, key)
+	}
+	return res
+}
+
+// ToSlice 返回集合中的所有元素，和 Keys 完全一致
+// 集合基于 map 实现，所以返回值的元素顺序是不定的，每次调用都可能不同
+// 集合为空的时候返回一个空切片而不是 nil
+func (s *Set[T]) ToSlice() []T {
+	return s.Keys()
+}
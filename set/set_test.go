@@ -0,0 +1,164 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package set
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet_Add(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keys     []int
+		wantLen  int
+		wantKeys []int
+	}{
+		{
+			name:     "empty",
+			wantLen:  0,
+			wantKeys: []int{},
+		},
+		{
+			name:     "no duplicate",
+			keys:     []int{1, 2, 3},
+			wantLen:  3,
+			wantKeys: []int{1, 2, 3},
+		},
+		{
+			name:     "duplicate",
+			keys:     []int{1, 2, 2, 3, 1},
+			wantLen:  3,
+			wantKeys: []int{1, 2, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewSet[int](len(tc.keys))
+			for _, key := range tc.keys {
+				s.Add(key)
+			}
+			assert.Equal(t, tc.wantLen, s.Len())
+			assert.ElementsMatch(t, tc.wantKeys, s.Keys())
+			assert.NotNil(t, s.Keys())
+		})
+	}
+}
+
+func TestSet_Remove(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keys     []int
+		remove   int
+		wantKeys []int
+	}{
+		{
+			name:     "empty",
+			remove:   1,
+			wantKeys: []int{},
+		},
+		{
+			name:     "exist",
+			keys:     []int{1, 2, 3},
+			remove:   2,
+			wantKeys: []int{1, 3},
+		},
+		{
+			name:     "not exist",
+			keys:     []int{1, 2, 3},
+			remove:   4,
+			wantKeys: []int{1, 2, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewSet[int](len(tc.keys))
+			for _, key := range tc.keys {
+				s.Add(key)
+			}
+			s.Remove(tc.remove)
+			assert.False(t, s.Contains(tc.remove))
+			assert.Equal(t, len(tc.wantKeys), s.Len())
+			assert.ElementsMatch(t, tc.wantKeys, s.Keys())
+		})
+	}
+}
+
+func TestSet_Contains(t *testing.T) {
+	s := NewSet[string](2)
+	s.Add("a")
+	s.Add("b")
+	assert.True(t, s.Contains("a"))
+	assert.True(t, s.Contains("b"))
+	assert.False(t, s.Contains("c"))
+}
+
+func TestSliceToSet(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      []int
+		wantKeys []int
+	}{
+		{
+			name:     "nil",
+			wantKeys: []int{},
+		},
+		{
+			name:     "no duplicate",
+			src:      []int{1, 2, 3},
+			wantKeys: []int{1, 2, 3},
+		},
+		{
+			name:     "duplicate",
+			src:      []int{3, 1, 3, 2, 1},
+			wantKeys: []int{1, 2, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := SliceToSet(tc.src)
+			assert.Equal(t, len(tc.wantKeys), s.Len())
+			res := s.ToSlice()
+			assert.NotNil(t, res)
+			assert.ElementsMatch(t, tc.wantKeys, res)
+		})
+	}
+}
+
+func ExampleSliceToSet() {
+	s := SliceToSet([]string{"b", "a", "b"})
+	res := s.ToSlice()
+	// 集合中的元素顺序不固定，排序之后再输出
+	sort.Strings(res)
+	fmt.Println(res)
+	// Output: [a b]
+}
+
+func ExampleNewSet() {
+	s := NewSet[int](4)
+	s.Add(1)
+	s.Add(2)
+	s.Add(1)
+	keys := s.Keys()
+	sort.Ints(keys)
+	fmt.Println(keys)
+	fmt.Println(s.Contains(2))
+	// Output:
+	// [1 2]
+	// true
+}
@@ -14,7 +14,12 @@
 
 package slice
 
-import "github.com/go-generic/internal/slice"
+import (
+	"slices"
+
+	"github.com/go-generic/internal/errs"
+	"github.com/go-generic/internal/slice"
+)
 
 // Add 在切片的index处添加元素
 // index 范围应为[0, len(src)]
@@ -23,3 +28,14 @@ func Add[Src any](src []Src, element Src, index int) ([]Src, error) {
 	res, err := slice.Add[Src](src, element, index)
 	return res, err
 }
+
+// Insert 在切片的 index 处插入一个或者多个元素，原来 index 及其之后的元素依次往后移动
+// index 范围应为[0, len(src)]，如果 index == len(src) 则表示往末尾追加元素
+// 和 append 一样，容量足够的时候会直接修改 src 的底层数组，所以调用者应该使用返回值
+func Insert[T any](src []T, index int, vals ...T) ([]T, error) {
+	length := len(src)
+	if index < 0 || index > length {
+		return nil, errs.NewErrIndexOutOfRange(length, index)
+	}
+	return slices.Insert(src, index, vals...), nil
+}
@@ -60,6 +60,86 @@ func TestAdd(t *testing.T) {
 	}
 }
 
+func TestInsert(t *testing.T) {
+	testCases := []struct {
+		name      string
+		slice     []int
+		index     int
+		vals      []int
+		wantSlice []int
+		wantErr   error
+	}{
+		{
+			name:      "nil",
+			index:     0,
+			vals:      []int{1, 2},
+			wantSlice: []int{1, 2},
+		},
+		{
+			name:      "head",
+			slice:     []int{123, 100},
+			index:     0,
+			vals:      []int{1, 2},
+			wantSlice: []int{1, 2, 123, 100},
+		},
+		{
+			name:      "middle",
+			slice:     []int{123, 100, 101},
+			index:     1,
+			vals:      []int{1, 2},
+			wantSlice: []int{123, 1, 2, 100, 101},
+		},
+		{
+			name:      "tail",
+			slice:     []int{123, 100},
+			index:     2,
+			vals:      []int{1},
+			wantSlice: []int{123, 100, 1},
+		},
+		{
+			name:      "no vals",
+			slice:     []int{123, 100},
+			index:     1,
+			wantSlice: []int{123, 100},
+		},
+		{
+			name:    "index -1",
+			slice:   []int{123, 100},
+			index:   -1,
+			vals:    []int{1},
+			wantErr: errs.NewErrIndexOutOfRange(2, -1),
+		},
+		{
+			name:    "index out of range",
+			slice:   []int{123, 100},
+			index:   3,
+			vals:    []int{1},
+			wantErr: errs.NewErrIndexOutOfRange(2, 3),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Insert(tc.slice, tc.index, tc.vals...)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantSlice, res)
+		})
+	}
+}
+
+func ExampleInsert() {
+	res, _ := Insert([]int{1, 4}, 1, 2, 3)
+	fmt.Println(res)
+	_, err := Insert([]int{1, 4}, 3, 2)
+	fmt.Println(err)
+	// Output:
+	// [1 2 3 4]
+	// ekit: 下标超出范围，长度 2, 下标 3
+}
+
 func ExampleAdd() {
 	res, _ := Add[int]([]int{1, 2, 3, 4}, 233, 2)
 	fmt.Println(res)
@@ -16,33 +16,76 @@ package slice
 
 import "github.com/go-generic"
 
-// Max 返回最大值。
-// 该方法假设你至少会传入一个值。
+// Max 返回最大值
+// 传入空切片的时候返回 ErrEmptySlice
 // 在使用 float32 或者 float64 的时候要小心精度问题
-func Max[T generic.RealNumber](ts []T) T {
+func Max[T generic.Ordered](ts []T) (T, error) {
+	if len(ts) == 0 {
+		var t T
+		return t, ErrEmptySlice
+	}
 	res := ts[0]
 	for i := 1; i < len(ts); i++ {
 		if ts[i] > res {
 			res = ts[i]
 		}
 	}
-	return res
+	return res, nil
 }
 
 // Min 返回最小值
-// 该方法会假设你至少会传入一个值
+// 传入空切片的时候返回 ErrEmptySlice
 // 在使用 float32 或者 float64 的时候要小心精度问题
-func Min[T generic.RealNumber](ts []T) T {
+func Min[T generic.Ordered](ts []T) (T, error) {
+	if len(ts) == 0 {
+		var t T
+		return t, ErrEmptySlice
+	}
 	res := ts[0]
 	for i := 1; i < len(ts); i++ {
 		if ts[i] < res {
 			res = ts[i]
 		}
 	}
-	return res
+	return res, nil
+}
+
+// MaxFunc 使用 compare 比较元素，返回最大值
+// 如果有多个最大值，返回第一个
+// 传入空切片的时候返回 ErrEmptySlice
+func MaxFunc[T any](ts []T, compare generic.Comparator[T]) (T, error) {
+	if len(ts) == 0 {
+		var t T
+		return t, ErrEmptySlice
+	}
+	res := ts[0]
+	for i := 1; i < len(ts); i++ {
+		if compare(ts[i], res) > 0 {
+			res = ts[i]
+		}
+	}
+	return res, nil
+}
+
+// MinFunc 使用 compare 比较元素，返回最小值
+// 如果有多个最小值，返回第一个
+// 传入空切片的时候返回 ErrEmptySlice
+func MinFunc[T any](ts []T, compare generic.Comparator[T]) (T, error) {
+	if len(ts) == 0 {
+		var t T
+		return t, ErrEmptySlice
+	}
+	res := ts[0]
+	for i := 1; i < len(ts); i++ {
+		if compare(ts[i], res) < 0 {
+			res = ts[i]
+		}
+	}
+	return res, nil
 }
 
 // Sum 求和
+// 传入空切片的时候返回零值
 // 在使用 float32 或者 float64 的时候要小心精度问题
 func Sum[T generic.Number](ts []T) T {
 	var res T
@@ -51,3 +94,35 @@ func Sum[T generic.Number](ts []T) T {
 	}
 	return res
 }
+
+// SumFunc 使用 fn 从每个元素中取出一个数字，然后求和，只遍历一次
+// 传入空切片的时候返回零值，fn 也不会被调用
+// 在使用 float32 或者 float64 的时候要小心精度问题
+func SumFunc[T any, N generic.Number](src []T, fn func(src T) N) N {
+	var res N
+	for _, t := range src {
+		res += fn(t)
+	}
+	return res
+}
+
+// Average 求平均值，使用 float64 计算，避免整数除法截断小数部分
+// 传入空切片的时候返回 ErrEmptySlice
+func Average[N generic.RealNumber](src []N) (float64, error) {
+	return AverageFunc(src, func(src N) N {
+		return src
+	})
+}
+
+// AverageFunc 使用 fn 从每个元素中取出一个数字，然后求平均值，使用 float64 计算
+// 传入空切片的时候返回 ErrEmptySlice，fn 也不会被调用
+func AverageFunc[T any, N generic.RealNumber](src []T, fn func(src T) N) (float64, error) {
+	if len(src) == 0 {
+		return 0, ErrEmptySlice
+	}
+	var sum float64
+	for _, t := range src {
+		sum += float64(fn(t))
+	}
+	return sum / float64(len(src)), nil
+}
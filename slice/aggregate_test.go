@@ -24,10 +24,20 @@ import (
 
 func TestMax(t *testing.T) {
 	testCases := []struct {
-		name  string
-		input []Integer
-		want  Integer
+		name    string
+		input   []Integer
+		want    Integer
+		wantErr error
 	}{
+		{
+			name:    "nil",
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:    "empty",
+			input:   []Integer{},
+			wantErr: ErrEmptySlice,
+		},
 		{
 			name:  "value",
 			input: []Integer{1},
@@ -42,18 +52,12 @@ func TestMax(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			res := Max[Integer](tc.input)
+			res, err := Max[Integer](tc.input)
+			assert.Equal(t, tc.wantErr, err)
 			assert.Equal(t, tc.want, res)
 		})
 	}
 
-	assert.Panics(t, func() {
-		Max[int](nil)
-	})
-	assert.Panics(t, func() {
-		Max[int]([]int{})
-	})
-
 	testMaxTypes[uint](t)
 	testMaxTypes[uint8](t)
 	testMaxTypes[uint16](t)
@@ -66,14 +70,29 @@ func TestMax(t *testing.T) {
 	testMaxTypes[int64](t)
 	testMaxTypes[float32](t)
 	testMaxTypes[float64](t)
+
+	// Ordered 也包括字符串
+	res, err := Max([]string{"b", "c", "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "c", res)
 }
 
 func TestMin(t *testing.T) {
 	testCases := []struct {
-		name  string
-		input []Integer
-		want  Integer
+		name    string
+		input   []Integer
+		want    Integer
+		wantErr error
 	}{
+		{
+			name:    "nil",
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:    "empty",
+			input:   []Integer{},
+			wantErr: ErrEmptySlice,
+		},
 		{
 			name:  "value",
 			input: []Integer{3},
@@ -88,18 +107,12 @@ func TestMin(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			res := Min[Integer](tc.input)
+			res, err := Min[Integer](tc.input)
+			assert.Equal(t, tc.wantErr, err)
 			assert.Equal(t, tc.want, res)
 		})
 	}
 
-	assert.Panics(t, func() {
-		Min[int](nil)
-	})
-	assert.Panics(t, func() {
-		Min[int]([]int{})
-	})
-
 	testMinTypes[uint](t)
 	testMinTypes[uint8](t)
 	testMinTypes[uint16](t)
@@ -112,6 +125,108 @@ func TestMin(t *testing.T) {
 	testMinTypes[int64](t)
 	testMinTypes[float32](t)
 	testMinTypes[float64](t)
+
+	// Ordered 也包括字符串
+	res, err := Min([]string{"b", "c", "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "a", res)
+}
+
+func TestMaxFunc(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   []aggregateElem
+		want    aggregateElem
+		wantErr error
+	}{
+		{
+			name:    "nil",
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:    "empty",
+			input:   []aggregateElem{},
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:  "value",
+			input: []aggregateElem{{id: 1, score: 1}},
+			want:  aggregateElem{id: 1, score: 1},
+		},
+		{
+			name:  "values",
+			input: []aggregateElem{{id: 1, score: 2}, {id: 2, score: 3}, {id: 3, score: 1}},
+			want:  aggregateElem{id: 2, score: 3},
+		},
+		{
+			name:  "duplicate max",
+			input: []aggregateElem{{id: 1, score: 3}, {id: 2, score: 3}, {id: 3, score: 1}},
+			want:  aggregateElem{id: 1, score: 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := MaxFunc(tc.input, compareAggregateElem)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestMinFunc(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   []aggregateElem
+		want    aggregateElem
+		wantErr error
+	}{
+		{
+			name:    "nil",
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:    "empty",
+			input:   []aggregateElem{},
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:  "value",
+			input: []aggregateElem{{id: 1, score: 1}},
+			want:  aggregateElem{id: 1, score: 1},
+		},
+		{
+			name:  "values",
+			input: []aggregateElem{{id: 1, score: 2}, {id: 2, score: 3}, {id: 3, score: 1}},
+			want:  aggregateElem{id: 3, score: 1},
+		},
+		{
+			name:  "duplicate min",
+			input: []aggregateElem{{id: 1, score: 1}, {id: 2, score: 3}, {id: 3, score: 1}},
+			want:  aggregateElem{id: 1, score: 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := MinFunc(tc.input, compareAggregateElem)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+type aggregateElem struct {
+	id    int
+	score int
+}
+
+func compareAggregateElem(src, dst aggregateElem) int {
+	return generic.ComparatorRealNumber(src.score, dst.score)
 }
 
 func TestSum(t *testing.T) {
@@ -159,15 +274,154 @@ func TestSum(t *testing.T) {
 	testSumTypes[float64](t)
 }
 
+func TestSumFunc(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input []aggregateElem
+		want  int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:  "empty",
+			input: []aggregateElem{},
+		},
+		{
+			name:  "value",
+			input: []aggregateElem{{id: 1, score: 10}},
+			want:  10,
+		},
+		{
+			name:  "values",
+			input: []aggregateElem{{id: 1, score: 10}, {id: 2, score: -3}, {id: 3, score: 5}},
+			want:  12,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := SumFunc(tc.input, func(src aggregateElem) int {
+				return src.score
+			})
+			assert.Equal(t, tc.want, res)
+		})
+	}
+
+	t.Run("float", func(t *testing.T) {
+		res := SumFunc([]string{"a", "bb", "ccc"}, func(src string) float64 {
+			return float64(len(src)) / 2
+		})
+		assert.Equal(t, 3.0, res)
+	})
+
+	t.Run("complex", func(t *testing.T) {
+		res := SumFunc([]int{1, 2}, func(src int) complex128 {
+			return complex(float64(src), 1)
+		})
+		assert.Equal(t, complex(3, 2), res)
+	})
+}
+
+func TestAverage(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   []int
+		want    float64
+		wantErr error
+	}{
+		{
+			name:    "nil",
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:    "empty",
+			input:   []int{},
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:  "value",
+			input: []int{3},
+			want:  3,
+		},
+		{
+			name:  "not truncated",
+			input: []int{1, 2},
+			want:  1.5,
+		},
+		{
+			name:  "negative",
+			input: []int{-1, -2, 6},
+			want:  1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Average(tc.input)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+
+	testAverageTypes[uint](t)
+	testAverageTypes[uint8](t)
+	testAverageTypes[uint16](t)
+	testAverageTypes[uint32](t)
+	testAverageTypes[uint64](t)
+	testAverageTypes[int](t)
+	testAverageTypes[int8](t)
+	testAverageTypes[int16](t)
+	testAverageTypes[int32](t)
+	testAverageTypes[int64](t)
+	testAverageTypes[float32](t)
+	testAverageTypes[float64](t)
+}
+
+func TestAverageFunc(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   []aggregateElem
+		want    float64
+		wantErr error
+	}{
+		{
+			name:    "nil",
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:  "values",
+			input: []aggregateElem{{id: 1, score: 1}, {id: 2, score: 2}, {id: 3, score: 4}},
+			want:  7.0 / 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := AverageFunc(tc.input, func(src aggregateElem) int {
+				return src.score
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.InDelta(t, tc.want, res, 1e-9)
+		})
+	}
+}
+
+// testAverageTypes 只是用来测试一下满足 Average 方法约束的所有类型
+func testAverageTypes[T generic.RealNumber](t *testing.T) {
+	res, err := Average[T]([]T{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, res)
+}
+
 // testMaxTypes 只是用来测试一下满足 Max 方法约束的所有类型
 func testMaxTypes[T generic.RealNumber](t *testing.T) {
-	res := Max[T]([]T{1, 2, 3})
+	res, err := Max[T]([]T{1, 2, 3})
+	assert.NoError(t, err)
 	assert.Equal(t, T(3), res)
 }
 
 // testMinTypes 只是用来测试一下满足 Min 方法约束的所有类型
 func testMinTypes[T generic.RealNumber](t *testing.T) {
-	res := Min[T]([]T{1, 2, 3})
+	res, err := Min[T]([]T{1, 2, 3})
+	assert.NoError(t, err)
 	assert.Equal(t, T(1), res)
 }
 
@@ -190,15 +444,56 @@ func ExampleSum() {
 }
 
 func ExampleMin() {
-	res := Min[int]([]int{1, 2, 3})
+	res, _ := Min[int]([]int{1, 2, 3})
 	fmt.Println(res)
+	_, err := Min[int](nil)
+	fmt.Println(err)
 	// Output:
 	// 1
+	// slice: 切片为空
+}
+
+func ExampleMaxFunc() {
+	type user struct {
+		name string
+		age  int
+	}
+	users := []user{{name: "Tom", age: 18}, {name: "Jerry", age: 20}}
+	res, _ := MaxFunc(users, func(src, dst user) int {
+		return generic.ComparatorRealNumber(src.age, dst.age)
+	})
+	fmt.Println(res.name)
+	// Output:
+	// Jerry
 }
 
 func ExampleMax() {
-	res := Max[int]([]int{1, 2, 3})
+	res, _ := Max[int]([]int{1, 2, 3})
 	fmt.Println(res)
 	// Output:
 	// 3
 }
+
+func ExampleSumFunc() {
+	type order struct {
+		id    int
+		price float64
+	}
+	orders := []order{{id: 1, price: 1.5}, {id: 2, price: 2.5}}
+	res := SumFunc(orders, func(src order) float64 {
+		return src.price
+	})
+	fmt.Println(res)
+	// Output:
+	// 4
+}
+
+func ExampleAverage() {
+	res, _ := Average([]int{1, 2, 3, 4})
+	fmt.Println(res)
+	_, err := Average([]int{})
+	fmt.Println(err)
+	// Output:
+	// 2.5
+	// slice: 切片为空
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+// All 判断是否所有元素都满足 p，遇到第一个不满足的元素就立刻返回 false
+// 切片为空的时候返回 true
+func All[T any](src []T, p matchFunc[T]) bool {
+	for _, v := range src {
+		if !p(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Any 判断是否存在满足 p 的元素，遇到第一个满足的元素就立刻返回 true
+// 切片为空的时候返回 false
+func Any[T any](src []T, p matchFunc[T]) bool {
+	for _, v := range src {
+		if p(v) {
+			return true
+		}
+	}
+	return false
+}
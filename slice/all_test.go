@@ -0,0 +1,111 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	testCases := []struct {
+		name      string
+		src       []int
+		want      bool
+		wantCalls int
+	}{
+		{
+			name: "nil",
+			want: true,
+		},
+		{
+			name: "empty",
+			src:  []int{},
+			want: true,
+		},
+		{
+			name:      "all matched",
+			src:       []int{2, 4, 6},
+			want:      true,
+			wantCalls: 3,
+		},
+		{
+			name:      "short circuit",
+			src:       []int{2, 3, 4, 5},
+			wantCalls: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			res := All(tc.src, func(src int) bool {
+				calls++
+				return src%2 == 0
+			})
+			assert.Equal(t, tc.want, res)
+			assert.Equal(t, tc.wantCalls, calls)
+		})
+	}
+}
+
+func TestAny(t *testing.T) {
+	testCases := []struct {
+		name      string
+		src       []int
+		want      bool
+		wantCalls int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "empty",
+			src:  []int{},
+		},
+		{
+			name:      "none matched",
+			src:       []int{1, 3, 5},
+			wantCalls: 3,
+		},
+		{
+			name:      "short circuit",
+			src:       []int{1, 2, 3, 4},
+			want:      true,
+			wantCalls: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			res := Any(tc.src, func(src int) bool {
+				calls++
+				return src%2 == 0
+			})
+			assert.Equal(t, tc.want, res)
+			assert.Equal(t, tc.wantCalls, calls)
+		})
+	}
+}
+
+func ExampleAll() {
+	even := func(src int) bool {
+		return src%2 == 0
+	}
+	fmt.Println(All([]int{2, 4}, even), Any([]int{1, 3}, even))
+	// Output:
+	// true false
+}
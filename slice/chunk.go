@@ -0,0 +1,63 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+// Chunk 将 src 按照 size 切分成若干个连续的子切片，最后一个子切片的长度可能小于 size
+// size <= 0 的时候会 panic
+// 注意：返回的子切片和 src 共享底层数组，修改子切片中的元素会影响 src，
+// 但是子切片的容量被限制为自身的长度，所以对子切片执行 append 不会覆盖 src 中后续的元素
+func Chunk[T any](src []T, size int) [][]T {
+	if size <= 0 {
+		panic("slice: Chunk 的 size 必须大于 0")
+	}
+	res := make([][]T, 0, (len(src)+size-1)/size)
+	for start := 0; start < len(src); start += size {
+		end := min(start+size, len(src))
+		res = append(res, src[start:end:end])
+	}
+	return res
+}
+
+// Window 返回 src 中所有长度为 size 的连续子切片，每次向后滑动一个元素，子切片之间会重叠
+// len(src) < size 的时候返回空切片；size <= 0 的时候会 panic
+// 注意：返回的子切片和 src 共享底层数组，修改子切片中的元素会影响 src 以及其它重叠的子切片，
+// 但是子切片的容量被限制为自身的长度，所以对子切片执行 append 不会覆盖 src 中后续的元素
+func Window[T any](src []T, size int) [][]T {
+	if size <= 0 {
+		panic("slice: Window 的 size 必须大于 0")
+	}
+	n := max(len(src)-size+1, 0)
+	res := make([][]T, 0, n)
+	for start := 0; start < n; start++ {
+		end := start + size
+		res = append(res, src[start:end:end])
+	}
+	return res
+}
+
+// Flatten 将二维切片按顺序拼接成一维切片，是 Chunk 的逆操作
+// 会预先计算总长度，只分配一次内存；nil 子切片会被跳过
+// 返回的是一个新的切片，不会和 src 共享底层数组
+func Flatten[T any](src [][]T) []T {
+	total := 0
+	for _, s := range src {
+		total += len(s)
+	}
+	res := make([]T, 0, total)
+	for _, s := range src {
+		res = append(res, s...)
+	}
+	return res
+}
@@ -0,0 +1,199 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []int
+		size int
+		want [][]int
+	}{
+		{
+			name: "src nil",
+			size: 2,
+			want: [][]int{},
+		},
+		{
+			name: "src empty",
+			src:  []int{},
+			size: 2,
+			want: [][]int{},
+		},
+		{
+			name: "divisible",
+			src:  []int{1, 2, 3, 4},
+			size: 2,
+			want: [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name: "not divisible",
+			src:  []int{1, 2, 3, 4, 5},
+			size: 2,
+			want: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name: "size larger than length",
+			src:  []int{1, 2, 3},
+			size: 5,
+			want: [][]int{{1, 2, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Chunk(tt.src, tt.size)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+
+	assert.Panics(t, func() {
+		Chunk([]int{1, 2}, 0)
+	})
+	assert.Panics(t, func() {
+		Chunk([]int{1, 2}, -1)
+	})
+}
+
+func TestChunkAppend(t *testing.T) {
+	src := []int{1, 2, 3, 4}
+	res := Chunk(src, 2)
+	// 子切片的容量被限制，append 不会覆盖 src 中的元素
+	_ = append(res[0], 100)
+	assert.Equal(t, []int{1, 2, 3, 4}, src)
+}
+
+func ExampleChunk() {
+	res := Chunk([]int{1, 2, 3, 4, 5}, 2)
+	fmt.Println(res)
+	// Output: [[1 2] [3 4] [5]]
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []int
+		size int
+		want [][]int
+	}{
+		{
+			name: "src nil",
+			size: 2,
+			want: [][]int{},
+		},
+		{
+			name: "size larger than length",
+			src:  []int{1, 2, 3},
+			size: 4,
+			want: [][]int{},
+		},
+		{
+			name: "size equals length",
+			src:  []int{1, 2, 3},
+			size: 3,
+			want: [][]int{{1, 2, 3}},
+		},
+		{
+			name: "size 1",
+			src:  []int{1, 2, 3},
+			size: 1,
+			want: [][]int{{1}, {2}, {3}},
+		},
+		{
+			name: "sliding",
+			src:  []int{1, 2, 3, 4, 5},
+			size: 3,
+			want: [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Window(tt.src, tt.size)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+
+	assert.Panics(t, func() {
+		Window([]int{1, 2}, 0)
+	})
+	assert.Panics(t, func() {
+		Window([]int{1, 2}, -1)
+	})
+}
+
+func TestWindowAppend(t *testing.T) {
+	src := []int{1, 2, 3, 4}
+	res := Window(src, 2)
+	// 子切片的容量被限制，append 不会覆盖 src 中的元素
+	_ = append(res[0], 100)
+	assert.Equal(t, []int{1, 2, 3, 4}, src)
+	// 子切片和 src 共享底层数组
+	res[1][0] = 200
+	assert.Equal(t, []int{1, 200, 3, 4}, src)
+	assert.Equal(t, 200, res[0][1])
+}
+
+func ExampleWindow() {
+	res := Window([]int{1, 2, 3, 4}, 2)
+	fmt.Println(res)
+	// Output: [[1 2] [2 3] [3 4]]
+}
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name string
+		src  [][]int
+		want []int
+	}{
+		{
+			name: "src nil",
+			want: []int{},
+		},
+		{
+			name: "src empty",
+			src:  [][]int{},
+			want: []int{},
+		},
+		{
+			name: "with nil inner slice",
+			src:  [][]int{{1, 2}, nil, {3}, {}},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "normal",
+			src:  [][]int{{1, 2}, {3, 4}, {5}},
+			want: []int{1, 2, 3, 4, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Flatten(tt.src)
+			assert.Equal(t, tt.want, res)
+			assert.Equal(t, len(tt.want), cap(res))
+		})
+	}
+}
+
+func ExampleFlatten() {
+	res := Flatten(Chunk([]int{1, 2, 3, 4, 5}, 2))
+	fmt.Println(res)
+	// Output: [1 2 3 4 5]
+}
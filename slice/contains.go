@@ -34,6 +34,7 @@ func ContainsFunc[T any](src []T, equal func(src T) bool) bool {
 }
 
 // ContainsAny 判断 src 里面是否存在 dst 中的任何一个元素
+// 先将 src 构造成 map，时间复杂度是 O(n+m)；dst 为空的时候返回 false
 func ContainsAny[T comparable](src, dst []T) bool {
 	srcMap := toMap[T](src)
 	for _, v := range dst {
@@ -58,6 +59,7 @@ func ContainsAnyFunc[T any](src, dst []T, equal equalFunc[T]) bool {
 }
 
 // ContainsAll 判断 src 里面是否存在 dst 中的所有元素
+// 先将 src 构造成 map，时间复杂度是 O(n+m)；dst 为空的时候返回 true
 func ContainsAll[T comparable](src, dst []T) bool {
 	srcMap := toMap[T](src)
 	for _, v := range dst {
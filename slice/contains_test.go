@@ -135,6 +135,12 @@ func TestContainsAny(t *testing.T) {
 			dst:  []int{1},
 			name: "src nil",
 		},
+		{
+			want: false,
+			src:  []int{1, 2},
+			dst:  []int{},
+			name: "dst empty",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -225,6 +231,12 @@ func TestContainsAll(t *testing.T) {
 			src:  nil,
 			name: "src and dst nil",
 		},
+		{
+			want: true,
+			src:  []int{1, 2},
+			dst:  []int{},
+			name: "dst empty",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -0,0 +1,34 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+// Count 返回和 dst 相等的元素个数
+func Count[T comparable](src []T, dst T) int {
+	return CountFunc[T](src, func(src T) bool {
+		return src == dst
+	})
+}
+
+// CountFunc 返回 match 返回 true 的元素个数
+// 你应该优先使用 Count
+func CountFunc[T any](src []T, match matchFunc[T]) int {
+	cnt := 0
+	for _, v := range src {
+		if match(v) {
+			cnt++
+		}
+	}
+	return cnt
+}
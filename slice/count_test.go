@@ -0,0 +1,104 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCount(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  []int
+		dst  int
+		want int
+	}{
+		{
+			name: "nil",
+			dst:  1,
+		},
+		{
+			name: "empty",
+			src:  []int{},
+			dst:  1,
+		},
+		{
+			name: "not found",
+			src:  []int{2, 3},
+			dst:  1,
+		},
+		{
+			name: "multiple",
+			src:  []int{1, 2, 1, 3, 1},
+			dst:  1,
+			want: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Count(tc.src, tc.dst))
+		})
+	}
+}
+
+func TestCountFunc(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  []int
+		want int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "empty",
+			src:  []int{},
+		},
+		{
+			name: "none matched",
+			src:  []int{1, 3},
+		},
+		{
+			name: "all matched",
+			src:  []int{2, 4},
+			want: 2,
+		},
+		{
+			name: "partial matched",
+			src:  []int{1, 2, 3, 4, 6},
+			want: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := CountFunc(tc.src, func(src int) bool {
+				return src%2 == 0
+			})
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func ExampleCountFunc() {
+	res := CountFunc([]int{1, 2, 3, 4}, func(src int) bool {
+		return src%2 == 0
+	})
+	fmt.Println(res)
+	// Output:
+	// 2
+}
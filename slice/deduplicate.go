@@ -0,0 +1,62 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+// Deduplicate 去除切片中的重复元素，并返回一个新的切片
+// 基于 map 实现，时间复杂度 O(n)，但是返回值的元素顺序是不定的
+// 如果需要保持元素的顺序，请使用 DeduplicateStable
+// 即使传入的切片为nil，也保证返回一个空切片而不是nil
+func Deduplicate[T comparable](src []T) []T {
+	// 将切片转成map，这样可以去除切片中重复的元素
+	dataMap := toMap[T](src)
+	var newData = make([]T, 0, len(dataMap))
+	for key := range dataMap {
+		newData = append(newData, key)
+	}
+	return newData
+}
+
+// DeduplicateFunc 去除切片中的重复元素，并返回一个新的切片，支持任意类型
+// 每个元素只保留最后一次出现的位置，返回的元素顺序和它们在 src 中最后一次出现的顺序一致
+// 时间复杂度 O(n^2)，你应该优先使用 Deduplicate 或者 DeduplicateStable
+// 即使传入的切片为nil，也保证返回一个空切片而不是nil
+func DeduplicateFunc[T any](src []T, equal equalFunc[T]) []T {
+	var newData = make([]T, 0, len(src))
+	for k, v := range src {
+		// 判断当前元素之后的所有元素中，是否存在等于v的元素，若是不存在就将v添加到newData切片中
+		if !ContainsFunc[T](src[k+1:], func(s T) bool {
+			return equal(s, v)
+		}) {
+			newData = append(newData, v)
+		}
+	}
+	return newData
+}
+
+// DeduplicateStable 去除切片中的重复元素，并返回一个新的切片
+// 每个元素只保留第一次出现的位置，返回的元素顺序和它们在 src 中第一次出现的顺序一致
+// 时间复杂度 O(n)。即使传入的切片为nil，也保证返回一个空切片而不是nil
+func DeduplicateStable[T comparable](src []T) []T {
+	seen := make(map[T]struct{}, len(src))
+	res := make([]T, 0, len(src))
+	for _, v := range src {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		res = append(res, v)
+	}
+	return res
+}
@@ -0,0 +1,159 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicate(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  []int
+		want []int
+	}{
+		{
+			name: "nil",
+			want: []int{},
+		},
+		{
+			name: "empty",
+			src:  []int{},
+			want: []int{},
+		},
+		{
+			name: "no duplicates",
+			src:  []int{3, 1, 2},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "all duplicates",
+			src:  []int{2, 2, 2},
+			want: []int{2},
+		},
+		{
+			name: "partial duplicates",
+			src:  []int{3, 1, 3, 2, 1, 4, 2},
+			want: []int{1, 2, 3, 4},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Deduplicate(tc.src)
+			assert.NotNil(t, res)
+			// 返回值的元素顺序是不定的
+			assert.ElementsMatch(t, tc.want, res)
+		})
+	}
+}
+
+func TestDeduplicateFunc(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  []int
+		want []int
+	}{
+		{
+			name: "nil",
+			want: []int{},
+		},
+		{
+			name: "empty",
+			src:  []int{},
+			want: []int{},
+		},
+		{
+			name: "no duplicates",
+			src:  []int{3, 1, 2},
+			want: []int{3, 1, 2},
+		},
+		{
+			name: "all duplicates",
+			src:  []int{2, 2, 2},
+			want: []int{2},
+		},
+		{
+			name: "keep last occurrence",
+			src:  []int{3, 1, 3, 2, 1, 4, 2},
+			want: []int{3, 1, 4, 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := DeduplicateFunc(tc.src, func(src, dst int) bool {
+				return src == dst
+			})
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func ExampleDeduplicateFunc() {
+	res := DeduplicateFunc([]string{"a", "B", "A", "b"}, func(src, dst string) bool {
+		return strings.EqualFold(src, dst)
+	})
+	fmt.Println(res)
+	// Output:
+	// [A b]
+}
+
+func TestDeduplicateStable(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  []int
+		want []int
+	}{
+		{
+			name: "nil",
+			want: []int{},
+		},
+		{
+			name: "empty",
+			src:  []int{},
+			want: []int{},
+		},
+		{
+			name: "no duplicates",
+			src:  []int{3, 1, 2},
+			want: []int{3, 1, 2},
+		},
+		{
+			name: "all duplicates",
+			src:  []int{2, 2, 2},
+			want: []int{2},
+		},
+		{
+			name: "keep first occurrence",
+			src:  []int{3, 1, 3, 2, 1, 4, 2},
+			want: []int{3, 1, 2, 4},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, DeduplicateStable(tc.src))
+		})
+	}
+}
+
+func ExampleDeduplicateStable() {
+	res := DeduplicateStable([]int{3, 1, 3, 2, 1})
+	fmt.Println(res)
+	// Output:
+	// [3 1 2]
+}
@@ -42,5 +42,5 @@ func DiffSetFunc[T any](src, dst []T, equal equalFunc[T]) []T {
 			ret = append(ret, val)
 		}
 	}
-	return deduplicateFunc[T](ret, equal)
+	return DeduplicateFunc[T](ret, equal)
 }
@@ -0,0 +1,38 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+// Equal 判断两个切片是否相等，即长度相同并且对应位置的元素都相等
+// 注意：nil 切片和空切片被认为是相等的
+func Equal[T comparable](a, b []T) bool {
+	return EqualFunc[T](a, b, func(src, dst T) bool {
+		return src == dst
+	})
+}
+
+// EqualFunc 判断两个切片是否相等，使用 equal 比较对应位置的元素
+// 长度不同的时候直接返回 false，不会调用 equal
+// 你应该优先使用 Equal
+func EqualFunc[T any](a, b []T, equal equalFunc[T]) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if !equal(v, b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []int
+		b    []int
+		want bool
+	}{
+		{
+			name: "both nil",
+			want: true,
+		},
+		{
+			name: "nil and empty",
+			b:    []int{},
+			want: true,
+		},
+		{
+			name: "empty and nil",
+			a:    []int{},
+			want: true,
+		},
+		{
+			name: "nil and not empty",
+			b:    []int{1},
+			want: false,
+		},
+		{
+			name: "equal",
+			a:    []int{1, 2, 3},
+			b:    []int{1, 2, 3},
+			want: true,
+		},
+		{
+			name: "different order",
+			a:    []int{1, 2, 3},
+			b:    []int{3, 2, 1},
+			want: false,
+		},
+		{
+			name: "different length",
+			a:    []int{1, 2, 3},
+			b:    []int{1, 2},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Equal(tt.a, tt.b))
+			// 相等是对称的
+			assert.Equal(t, tt.want, Equal(tt.b, tt.a))
+		})
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want bool
+	}{
+		{
+			name: "nil and empty",
+			b:    []string{},
+			want: true,
+		},
+		{
+			name: "equal ignore case",
+			a:    []string{"a", "B"},
+			b:    []string{"A", "b"},
+			want: true,
+		},
+		{
+			name: "not equal",
+			a:    []string{"a", "b"},
+			b:    []string{"a", "c"},
+			want: false,
+		},
+		{
+			name: "different length",
+			a:    []string{"a"},
+			b:    []string{"a", "a"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := EqualFunc(tt.a, tt.b, func(src, dst string) bool {
+				return strings.EqualFold(src, dst)
+			})
+			assert.Equal(t, tt.want, res)
+		})
+	}
+
+	t.Run("different length not call equal", func(t *testing.T) {
+		res := EqualFunc([]int{1}, []int{1, 2}, func(src, dst int) bool {
+			panic("equal 不应该被调用")
+		})
+		assert.False(t, res)
+	})
+}
+
+func ExampleEqual() {
+	fmt.Println(Equal([]int{1, 2}, []int{1, 2}))
+	fmt.Println(Equal([]int{}, nil))
+	fmt.Println(Equal([]int{1, 2}, []int{2, 1}))
+	// Output:
+	// true
+	// true
+	// false
+}
@@ -0,0 +1,31 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"errors"
+
+	"github.com/go-generic/internal/errs"
+)
+
+var (
+	// ErrEmptySlice 切片为空
+	ErrEmptySlice = errors.New("slice: 切片为空")
+	// ErrIndexOutOfRange 下标超出范围，Get、Insert、Delete 等方法返回的 IndexOutOfRangeError 都可以通过 errors.Is 匹配到它
+	ErrIndexOutOfRange = errs.ErrIndexOutOfRange
+)
+
+// IndexOutOfRangeError 下标超出范围的错误，带上了访问的下标和当时的长度，可以通过 errors.As 获取
+type IndexOutOfRangeError = errs.IndexOutOfRangeError
@@ -0,0 +1,31 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+// Repeat 返回一个包含 count 个 val 的新切片
+// count <= 0 的时候返回一个空切片而不是nil
+// 注意：val 是浅拷贝的，如果 val 是指针、切片或者 map，所有的元素会共享同一份数据
+func Repeat[T any](val T, count int) []T {
+	res := make([]T, max(count, 0))
+	Fill(res, val)
+	return res
+}
+
+// Fill 使用 val 覆盖 dst 中的每一个元素，dst 的长度保持不变
+func Fill[T any](dst []T, val T) {
+	for i := range dst {
+		dst[i] = val
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepeat(t *testing.T) {
+	tests := []struct {
+		name  string
+		val   string
+		count int
+		want  []string
+	}{
+		{
+			name:  "count negative",
+			val:   "a",
+			count: -1,
+			want:  []string{},
+		},
+		{
+			name:  "count zero",
+			val:   "a",
+			count: 0,
+			want:  []string{},
+		},
+		{
+			name:  "count one",
+			val:   "a",
+			count: 1,
+			want:  []string{"a"},
+		},
+		{
+			name:  "multiple",
+			val:   "a",
+			count: 3,
+			want:  []string{"a", "a", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Repeat(tt.val, tt.count)
+			assert.Equal(t, tt.want, res)
+			assert.Equal(t, len(tt.want), cap(res))
+		})
+	}
+}
+
+func TestFill(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  []int
+		val  int
+		want []int
+	}{
+		{
+			name: "dst nil",
+			val:  1,
+		},
+		{
+			name: "dst empty",
+			dst:  []int{},
+			val:  1,
+			want: []int{},
+		},
+		{
+			name: "multiple",
+			dst:  []int{1, 2, 3},
+			val:  6,
+			want: []int{6, 6, 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Fill(tt.dst, tt.val)
+			assert.Equal(t, tt.want, tt.dst)
+		})
+	}
+}
+
+func ExampleRepeat() {
+	fmt.Println(Repeat(7, 3))
+	// Output: [7 7 7]
+}
+
+func ExampleFill() {
+	dst := make([]int, 4)
+	Fill(dst, -1)
+	fmt.Println(dst)
+	// Output: [-1 -1 -1 -1]
+}
@@ -26,6 +26,18 @@ func Find[T any](src []T, match matchFunc[T]) (T, bool) {
 	return t, false
 }
 
+// FindLast 从后往前查找元素，返回最后一个满足 match 的元素
+// 如果没有找到，第二个返回值返回 false
+func FindLast[T any](src []T, match matchFunc[T]) (T, bool) {
+	for i := len(src) - 1; i >= 0; i-- {
+		if match(src[i]) {
+			return src[i], true
+		}
+	}
+	var t T
+	return t, false
+}
+
 // FindAll 查找所有符合条件的元素
 // 永远不会返回 nil
 func FindAll[T any](src []T, match matchFunc[T]) []T {
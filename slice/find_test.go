@@ -75,6 +75,73 @@ func TestFind(t *testing.T) {
 	}
 }
 
+func TestFindLast(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input []Number
+		match matchFunc[Number]
+
+		wantVal Number
+		found   bool
+	}{
+		{
+			name: "找到了最后一个",
+			input: []Number{
+				{val: 123},
+				{val: 234},
+				{val: 345},
+			},
+			match: func(src Number) bool {
+				return src.val < 300
+			},
+			wantVal: Number{val: 234},
+			found:   true,
+		},
+		{
+			name: "第一个元素",
+			input: []Number{
+				{val: 123},
+				{val: 234},
+			},
+			match: func(src Number) bool {
+				return src.val == 123
+			},
+			wantVal: Number{val: 123},
+			found:   true,
+		},
+		{
+			name: "没找到",
+			input: []Number{
+				{val: 123},
+				{val: 234},
+			},
+			match: func(src Number) bool {
+				return src.val == 456
+			},
+		},
+		{
+			name: "nil",
+			match: func(src Number) bool {
+				return src.val == 123
+			},
+		},
+		{
+			name:  "没有元素",
+			input: []Number{},
+			match: func(src Number) bool {
+				return src.val == 123
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			val, found := FindLast[Number](tc.input, tc.match)
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.wantVal, val)
+		})
+	}
+}
+
 func TestFindAll(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -130,6 +197,15 @@ func ExampleFind() {
 	// 0 false
 }
 
+func ExampleFindLast() {
+	val, ok := FindLast[int]([]int{1, 2, 3, 4}, func(src int) bool {
+		return src%2 == 1
+	})
+	fmt.Println(val, ok)
+	// Output:
+	// 3 true
+}
+
 func ExampleFindAll() {
 	vals := FindAll[int]([]int{2, 3, 4}, func(src int) bool {
 		return src%2 == 1
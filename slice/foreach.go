@@ -0,0 +1,34 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+// ForEach 从前往后遍历切片，对每一个元素调用 fn，fn 返回 false 的时候会中断遍历
+// 只是为了执行副作用，不会像 Map 那样创建新的切片
+func ForEach[T any](src []T, fn func(idx int, val T) bool) {
+	for i, v := range src {
+		if !fn(i, v) {
+			return
+		}
+	}
+}
+
+// ForEachReverse 和 ForEach 一样，但是从后往前遍历，idx 依旧是元素在 src 中的下标
+func ForEachReverse[T any](src []T, fn func(idx int, val T) bool) {
+	for i := len(src) - 1; i >= 0; i-- {
+		if !fn(i, src[i]) {
+			return
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEach(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      []int
+		stopAt   int
+		wantIdxs []int
+		wantVals []int
+	}{
+		{
+			name:   "src nil",
+			stopAt: -1,
+		},
+		{
+			name:     "all",
+			src:      []int{1, 2, 3},
+			stopAt:   -1,
+			wantIdxs: []int{0, 1, 2},
+			wantVals: []int{1, 2, 3},
+		},
+		{
+			name:     "stop at first",
+			src:      []int{1, 2, 3},
+			stopAt:   1,
+			wantIdxs: []int{0},
+			wantVals: []int{1},
+		},
+		{
+			name:     "stop in middle",
+			src:      []int{1, 2, 3},
+			stopAt:   2,
+			wantIdxs: []int{0, 1},
+			wantVals: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var idxs, vals []int
+			ForEach(tt.src, func(idx int, val int) bool {
+				idxs = append(idxs, idx)
+				vals = append(vals, val)
+				return val != tt.stopAt
+			})
+			assert.Equal(t, tt.wantIdxs, idxs)
+			assert.Equal(t, tt.wantVals, vals)
+		})
+	}
+}
+
+func TestForEachReverse(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      []int
+		stopAt   int
+		wantIdxs []int
+		wantVals []int
+	}{
+		{
+			name:   "src nil",
+			stopAt: -1,
+		},
+		{
+			name:     "all",
+			src:      []int{1, 2, 3},
+			stopAt:   -1,
+			wantIdxs: []int{2, 1, 0},
+			wantVals: []int{3, 2, 1},
+		},
+		{
+			name:     "stop at first",
+			src:      []int{1, 2, 3},
+			stopAt:   3,
+			wantIdxs: []int{2},
+			wantVals: []int{3},
+		},
+		{
+			name:     "stop in middle",
+			src:      []int{1, 2, 3},
+			stopAt:   2,
+			wantIdxs: []int{2, 1},
+			wantVals: []int{3, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var idxs, vals []int
+			ForEachReverse(tt.src, func(idx int, val int) bool {
+				idxs = append(idxs, idx)
+				vals = append(vals, val)
+				return val != tt.stopAt
+			})
+			assert.Equal(t, tt.wantIdxs, idxs)
+			assert.Equal(t, tt.wantVals, vals)
+		})
+	}
+}
+
+func ExampleForEach() {
+	ForEach([]int{1, 2, 3, 4}, func(idx int, val int) bool {
+		fmt.Println(idx, val)
+		// 遇到 2 之后停止遍历
+		return val != 2
+	})
+	// Output:
+	// 0 1
+	// 1 2
+}
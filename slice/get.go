@@ -0,0 +1,33 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import "github.com/go-generic/internal/errs"
+
+// Get 返回 index 处的元素，下标超出范围的时候返回错误而不是 panic
+// 支持负数下标，表示从末尾开始计数：-1 是最后一个元素，-len(src) 是第一个元素
+// 所以合法的下标范围是 [-len(src), len(src))，返回的错误里面是调用者传入的原始下标
+func Get[T any](src []T, index int) (T, error) {
+	length := len(src)
+	i := index
+	if i < 0 {
+		i += length
+	}
+	if i < 0 || i >= length {
+		var t T
+		return t, errs.NewErrIndexOutOfRange(length, index)
+	}
+	return src[i], nil
+}
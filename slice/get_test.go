@@ -0,0 +1,139 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-generic/internal/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	testCases := []struct {
+		name    string
+		src     []int
+		index   int
+		wantVal int
+		wantErr error
+	}{
+		{
+			name:    "nil",
+			index:   0,
+			wantErr: errs.NewErrIndexOutOfRange(0, 0),
+		},
+		{
+			name:    "first",
+			src:     []int{1, 2, 3},
+			index:   0,
+			wantVal: 1,
+		},
+		{
+			name:    "last",
+			src:     []int{1, 2, 3},
+			index:   2,
+			wantVal: 3,
+		},
+		{
+			name:    "index out of range",
+			src:     []int{1, 2, 3},
+			index:   3,
+			wantErr: errs.NewErrIndexOutOfRange(3, 3),
+		},
+		{
+			name:    "negative last",
+			src:     []int{1, 2, 3},
+			index:   -1,
+			wantVal: 3,
+		},
+		{
+			name:    "negative first",
+			src:     []int{1, 2, 3},
+			index:   -3,
+			wantVal: 1,
+		},
+		{
+			name:    "negative out of range",
+			src:     []int{1, 2, 3},
+			index:   -4,
+			wantErr: errs.NewErrIndexOutOfRange(3, -4),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := Get(tc.src, tc.index)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantVal, val)
+		})
+	}
+}
+
+func TestIndexOutOfRangeError(t *testing.T) {
+	errFuncs := map[string]func() error{
+		"Get": func() error {
+			_, err := Get([]int{1, 2, 3}, 3)
+			return err
+		},
+		"Insert": func() error {
+			_, err := Insert([]int{1, 2, 3}, 4, 1)
+			return err
+		},
+		"Add": func() error {
+			_, err := Add([]int{1, 2, 3}, 1, 4)
+			return err
+		},
+		"Delete": func() error {
+			_, err := Delete([]int{1, 2, 3}, 3)
+			return err
+		},
+	}
+	for name, fn := range errFuncs {
+		t.Run(name, func(t *testing.T) {
+			err := fn()
+			assert.ErrorIs(t, err, ErrIndexOutOfRange)
+			var idxErr *IndexOutOfRangeError
+			assert.True(t, errors.As(err, &idxErr))
+			assert.Equal(t, 3, idxErr.Length)
+			assert.Greater(t, idxErr.Index, 2)
+		})
+	}
+
+	t.Run("wrapped", func(t *testing.T) {
+		_, err := Get([]int{}, 0)
+		err = fmt.Errorf("业务错误 %w", err)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+		var idxErr *IndexOutOfRangeError
+		assert.True(t, errors.As(err, &idxErr))
+		assert.Equal(t, IndexOutOfRangeError{Length: 0, Index: 0}, *idxErr)
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		assert.NotErrorIs(t, ErrEmptySlice, ErrIndexOutOfRange)
+	})
+}
+
+func ExampleGet() {
+	src := []int{1, 2, 3}
+	first, _ := Get(src, 0)
+	last, _ := Get(src, -1)
+	fmt.Println(first, last)
+	_, err := Get(src, 3)
+	fmt.Println(err)
+	// Output:
+	// 1 3
+	// ekit: 下标超出范围，长度 3, 下标 3
+}
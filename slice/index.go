@@ -42,7 +42,7 @@ func LastIndex[T comparable](src []T, dst T) int {
 	})
 }
 
-// LastIndexFunc 返回和 dst 相等的最后一个元素下标
+// LastIndexFunc 返回 match 返回 true 的最后一个下标
 // -1 表示没找到
 // 你应该优先使用 LastIndex
 func LastIndexFunc[T any](src []T, match matchFunc[T]) int {
@@ -16,6 +16,7 @@ package slice
 
 // IntersectSet 取交集，只支持 comparable 类型
 // 已去重
+// 返回值的元素顺序是不定的
 func IntersectSet[T comparable](src []T, dst []T) []T {
 	srcMap := toMap(src)
 	var ret = make([]T, 0, len(src))
@@ -25,11 +26,11 @@ func IntersectSet[T comparable](src []T, dst []T) []T {
 			ret = append(ret, val)
 		}
 	}
-	return deduplicate[T](ret)
+	return Deduplicate[T](ret)
 }
 
 // IntersectSetFunc 支持任意类型
-// 你应该优先使用 Intersect
+// 你应该优先使用 IntersectSet
 // 已去重
 func IntersectSetFunc[T any](src []T, dst []T, equal equalFunc[T]) []T {
 	var ret = make([]T, 0, len(src))
@@ -40,5 +41,5 @@ func IntersectSetFunc[T any](src []T, dst []T, equal equalFunc[T]) []T {
 			ret = append(ret, v)
 		}
 	}
-	return deduplicateFunc[T](ret, equal)
+	return DeduplicateFunc[T](ret, equal)
 }
@@ -14,6 +14,36 @@
 
 package slice
 
+import "fmt"
+
+// Filter 执行过滤，保留 p 返回 true 的元素，元素顺序保持不变
+// 即使传入的切片为nil，也保证返回一个空切片而不是nil
+func Filter[T any](src []T, p func(idx int, src T) bool) []T {
+	res := make([]T, 0, len(src))
+	for i, s := range src {
+		if p(i, s) {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// Partition 按照 p 的结果将切片拆分成两部分，只遍历一次，元素顺序保持不变
+// matched 是 p 返回 true 的元素，rest 是其余的元素
+// 即使传入的切片为nil，也保证返回两个空切片而不是nil
+func Partition[T any](src []T, p func(src T) bool) (matched []T, rest []T) {
+	matched = make([]T, 0, len(src))
+	rest = make([]T, 0, len(src))
+	for _, s := range src {
+		if p(s) {
+			matched = append(matched, s)
+		} else {
+			rest = append(rest, s)
+		}
+	}
+	return matched, rest
+}
+
 // FilterMap 执行过滤并且转化
 // 如果 m 的第二个返回值是 false，那么我们会忽略第一个返回值
 // 即便第二个返回值是 false，后续的元素依旧会被遍历
@@ -37,6 +67,42 @@ func Map[Src any, Dst any](src []Src, m func(idx int, src Src) Dst) []Dst {
 	return dst
 }
 
+// FlatMap 对每个元素调用 m，然后按照顺序将 m 返回的切片拼接起来
+// 每个元素可以映射成零个或者多个元素，相比 Map 之后再 Flatten，不需要中间的 [][]Dst
+// 即使传入的切片为nil，也保证返回一个空切片而不是nil
+func FlatMap[Src any, Dst any](src []Src, m func(idx int, src Src) []Dst) []Dst {
+	// 假设每个元素平均映射成一个元素，预先分配容量，减少扩容的次数
+	res := make([]Dst, 0, len(src))
+	for i, s := range src {
+		res = append(res, m(i, s)...)
+	}
+	return res
+}
+
+// Transform 使用 fn 的返回值原地覆盖 src 中的每一个元素，不会创建新的切片
+// 适合元素类型不变的映射，可以省掉 Map 的内存分配
+// 注意：src 会被修改，和 src 共享底层数组的其它切片也能看到修改
+func Transform[T any](src []T, fn func(idx int, v T) T) {
+	for i, v := range src {
+		src[i] = fn(i, v)
+	}
+}
+
+// MapError 和 Map 一样，但是 m 可以返回 error
+// 遇到第一个 error 的时候立刻返回，后续的元素不会再被处理
+// 返回的 error 会带上出错元素的下标，可以使用 errors.Is 或者 errors.As 判断 m 返回的原始 error
+func MapError[Src any, Dst any](src []Src, m func(idx int, src Src) (Dst, error)) ([]Dst, error) {
+	dst := make([]Dst, len(src))
+	for i, s := range src {
+		d, err := m(i, s)
+		if err != nil {
+			return nil, fmt.Errorf("slice: 下标 %d 处的元素映射失败 %w", i, err)
+		}
+		dst[i] = d
+	}
+	return dst, nil
+}
+
 // ToMap 将[]Ele映射到map[Key]Ele
 // 从Ele中提取Key的函数fn由使用者提供
 //
@@ -50,7 +116,7 @@ func Map[Src any, Dst any](src []Src, m func(idx int, src Src) Dst) []Dst {
 // 满足key_i == key_j 的情况，则在返回结果的resultMap中
 // resultMap[key_i] = val_j
 //
-// 即使传入的字符串为nil，也保证返回的map是一个空map而不是nil
+// 即使传入的切片为nil，也保证返回的map是一个空map而不是nil，fn 也不会被调用
 func ToMap[Ele any, Key comparable](elements []Ele, fn func(element Ele) Key) map[Key]Ele {
 	return ToMapV(
 		elements,
@@ -72,7 +138,7 @@ func ToMap[Ele any, Key comparable](elements []Ele, fn func(element Ele) Key) ma
 // 满足key_i == key_j 的情况，则在返回结果的resultMap中
 // resultMap[key_i] = val_j
 //
-// 即使传入的字符串为nil，也保证返回的map是一个空map而不是nil
+// 即使传入的切片为nil，也保证返回的map是一个空map而不是nil，fn 也不会被调用
 func ToMapV[Ele any, Key comparable, Val any](elements []Ele, fn func(element Ele) (Key, Val)) (resultMap map[Key]Val) {
 	resultMap = make(map[Key]Val, len(elements))
 	for _, element := range elements {
@@ -82,6 +148,36 @@ func ToMapV[Ele any, Key comparable, Val any](elements []Ele, fn func(element El
 	return
 }
 
+// ToMapVError 和 ToMapV 一样，但是 fn 可以返回 error
+// 遇到第一个 error 的时候立刻返回 nil 和 error，后续的元素不会再被处理
+// 返回的 error 会带上出错元素的下标，可以使用 errors.Is 或者 errors.As 判断 fn 返回的原始 error
+// 没有出错的时候，行为和 ToMapV 完全一致，包括重复 key 的处理
+func ToMapVError[Ele any, Key comparable, Val any](elements []Ele, fn func(element Ele) (Key, Val, error)) (map[Key]Val, error) {
+	resultMap := make(map[Key]Val, len(elements))
+	for i, element := range elements {
+		k, v, err := fn(element)
+		if err != nil {
+			return nil, fmt.Errorf("slice: 下标 %d 处的元素映射失败 %w", i, err)
+		}
+		resultMap[k] = v
+	}
+	return resultMap, nil
+}
+
+// GroupBy 将[]Ele按照key分组，映射到map[Key][]Ele
+// 从Ele中提取Key的函数fn由使用者提供
+// 同一个分组内的元素保持它们在elements中的相对顺序
+//
+// 即使传入的切片为nil，也保证返回的map是一个空map而不是nil
+func GroupBy[Ele any, Key comparable](elements []Ele, fn func(element Ele) Key) map[Key][]Ele {
+	resultMap := make(map[Key][]Ele)
+	for _, element := range elements {
+		k := fn(element)
+		resultMap[k] = append(resultMap[k], element)
+	}
+	return resultMap
+}
+
 // 构造map（key是切片元素 value是空结构体）
 func toMap[T comparable](src []T) map[T]struct{} {
 	var dataMap = make(map[T]struct{}, len(src))
@@ -91,31 +187,3 @@ func toMap[T comparable](src []T) map[T]struct{} {
 	}
 	return dataMap
 }
-
-// deduplicateFunc 去除输入切片中的重复元素,并返回一个新的切片（equal函数作为参数传入）
-func deduplicateFunc[T any](data []T, equal equalFunc[T]) []T {
-	var newData = make([]T, 0, len(data))
-	// 遍历切片
-	for k, v := range data {
-		// k为index，v为元素值
-		// 判断当前切片元素之后的所有元素中，是否存在等于v的元素，若是不存在就将v添加到newData切片中
-		// 用此方法可以去重
-		if !ContainsFunc[T](data[k+1:], func(src T) bool {
-			return equal(src, v)
-		}) {
-			newData = append(newData, v)
-		}
-	}
-	return newData
-}
-
-// deduplicate 去除输入切片中的重复元素,并返回一个新的切片
-func deduplicate[T comparable](data []T) []T {
-	// 将切片转成map，这样可以去除切片中重复的元素
-	dataMap := toMap[T](data)
-	var newData = make([]T, 0, len(dataMap))
-	for key := range dataMap {
-		newData = append(newData, key)
-	}
-	return newData
-}
@@ -15,11 +15,14 @@
 package slice
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMap(t *testing.T) {
@@ -62,6 +65,108 @@ func ExampleMap() {
 	// Output: [1 2 3]
 }
 
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []int
+		want []int
+	}{
+		{
+			name: "src nil",
+			want: []int{},
+		},
+		{
+			name: "src empty",
+			src:  []int{},
+			want: []int{},
+		},
+		{
+			name: "src has element",
+			src:  []int{1, -2, 3, -4},
+			want: []int{1, 3},
+		},
+		{
+			name: "all filtered",
+			src:  []int{-1, -2},
+			want: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Filter(tt.src, func(idx int, src int) bool {
+				return src >= 0
+			})
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func ExampleFilter() {
+	src := []int{1, -2, 3}
+	dst := Filter(src, func(idx int, src int) bool {
+		return src >= 0
+	})
+	fmt.Println(dst)
+	// Output: [1 3]
+}
+
+func TestPartition(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         []int
+		wantMatched []int
+		wantRest    []int
+	}{
+		{
+			name:        "src nil",
+			wantMatched: []int{},
+			wantRest:    []int{},
+		},
+		{
+			name:        "src empty",
+			src:         []int{},
+			wantMatched: []int{},
+			wantRest:    []int{},
+		},
+		{
+			name:        "src has element",
+			src:         []int{1, -2, 3, -4},
+			wantMatched: []int{1, 3},
+			wantRest:    []int{-2, -4},
+		},
+		{
+			name:        "all matched",
+			src:         []int{1, 2},
+			wantMatched: []int{1, 2},
+			wantRest:    []int{},
+		},
+		{
+			name:        "none matched",
+			src:         []int{-1, -2},
+			wantMatched: []int{},
+			wantRest:    []int{-1, -2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, rest := Partition(tt.src, func(src int) bool {
+				return src >= 0
+			})
+			assert.Equal(t, tt.wantMatched, matched)
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+}
+
+func ExamplePartition() {
+	matched, rest := Partition([]int{1, -2, 3, -4}, func(src int) bool {
+		return src >= 0
+	})
+	fmt.Println(matched, rest)
+	// Output:
+	// [1 3] [-2 -4]
+}
+
 func TestFilterMap(t *testing.T) {
 	tests := []struct {
 		name string
@@ -102,6 +207,153 @@ func ExampleFilterMap() {
 	// Output: [1 3]
 }
 
+func TestFlatMap(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []int
+		want []string
+	}{
+		{
+			name: "src nil",
+			want: []string{},
+		},
+		{
+			name: "src empty",
+			src:  []int{},
+			want: []string{},
+		},
+		{
+			name: "zero output",
+			src:  []int{0, 0},
+			want: []string{},
+		},
+		{
+			name: "multiple output",
+			src:  []int{1, 0, 2, 3},
+			want: []string{"0", "2", "2", "3", "3", "3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := FlatMap(tt.src, func(idx int, src int) []string {
+				// 每个元素重复 src 次，内容是下标
+				res := make([]string, 0, src)
+				for i := 0; i < src; i++ {
+					res = append(res, strconv.Itoa(idx))
+				}
+				return res
+			})
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func ExampleFlatMap() {
+	src := []string{"a b", "", "c"}
+	res := FlatMap(src, func(idx int, src string) []string {
+		return strings.Fields(src)
+	})
+	fmt.Println(res)
+	// Output: [a b c]
+}
+
+func TestTransform(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []int
+		want []int
+	}{
+		{
+			name: "src nil",
+		},
+		{
+			name: "src empty",
+			src:  []int{},
+			want: []int{},
+		},
+		{
+			name: "multiple",
+			src:  []int{1, 2, 3},
+			want: []int{1, 3, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Transform(tt.src, func(idx int, v int) int {
+				return idx + v
+			})
+			assert.Equal(t, tt.want, tt.src)
+		})
+	}
+
+	t.Run("share backing array", func(t *testing.T) {
+		src := []int{1, 2, 3, 4}
+		sub := src[1:3]
+		Transform(sub, func(idx int, v int) int {
+			return v * 10
+		})
+		assert.Equal(t, []int{1, 20, 30, 4}, src)
+	})
+}
+
+func ExampleTransform() {
+	src := []string{"a", "b", "c"}
+	Transform(src, func(idx int, v string) string {
+		return strings.ToUpper(v)
+	})
+	fmt.Println(src)
+	// Output: [A B C]
+}
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     []string
+		want    []int
+		wantErr error
+	}{
+		{
+			name: "src nil",
+			want: []int{},
+		},
+		{
+			name: "src empty",
+			src:  []string{},
+			want: []int{},
+		},
+		{
+			name: "src has element",
+			src:  []string{"1", "2", "3"},
+			want: []int{1, 2, 3},
+		},
+		{
+			name:    "error",
+			src:     []string{"1", "a", "3"},
+			wantErr: fmt.Errorf("slice: 下标 1 处的元素映射失败 %w", errors.New("mock error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			res, err := MapError(tt.src, func(idx int, src string) (int, error) {
+				calls++
+				val, err := strconv.Atoi(src)
+				if err != nil {
+					return 0, errors.New("mock error")
+				}
+				return val, nil
+			})
+			assert.Equal(t, tt.wantErr, err)
+			if err != nil {
+				// 遇到错误之后立刻返回
+				assert.Equal(t, 2, calls)
+				return
+			}
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
 func TestToMapV(t *testing.T) {
 	t.Run("integer-string to map[int]int", func(t *testing.T) {
 		elements := []string{"1", "2", "3", "4", "5"}
@@ -198,14 +450,81 @@ func TestToMapV(t *testing.T) {
 	t.Run("传入nil slice,返回空map", func(t *testing.T) {
 		var elements []string = nil
 		resMap := ToMapV(elements, func(str string) (int, int) {
-			num, _ := strconv.Atoi(str)
-			return num, num
+			t.Fatal("fn 不应该被调用")
+			return 0, 0
 		})
+		require.NotNil(t, resMap)
 		epectedMap := make(map[int]int)
 		assert.Equal(t, epectedMap, resMap)
+		// 返回的 map 可以直接写入
+		resMap[1] = 1
+	})
+
+	t.Run("传入空 slice,返回空map", func(t *testing.T) {
+		resMap := ToMapV([]string{}, func(str string) (int, int) {
+			num, _ := strconv.Atoi(str)
+			return num, num
+		})
+		require.NotNil(t, resMap)
+		assert.Empty(t, resMap)
 	})
 }
 
+func TestToMapVError(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     []string
+		want    map[int]string
+		wantErr error
+	}{
+		{
+			name: "src nil",
+			want: map[int]string{},
+		},
+		{
+			name: "src empty",
+			src:  []string{},
+			want: map[int]string{},
+		},
+		{
+			name: "src has element",
+			src:  []string{"1", "2", "3"},
+			want: map[int]string{1: "1", 2: "2", 3: "3"},
+		},
+		{
+			name: "duplicate key",
+			src:  []string{"1", "01", "2"},
+			want: map[int]string{1: "01", 2: "2"},
+		},
+		{
+			name:    "error",
+			src:     []string{"1", "a", "3"},
+			wantErr: fmt.Errorf("slice: 下标 1 处的元素映射失败 %w", errors.New("mock error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			res, err := ToMapVError(tt.src, func(src string) (int, string, error) {
+				calls++
+				key, err := strconv.Atoi(src)
+				if err != nil {
+					return 0, "", errors.New("mock error")
+				}
+				return key, src, nil
+			})
+			assert.Equal(t, tt.wantErr, err)
+			if err != nil {
+				assert.Nil(t, res)
+				// 遇到错误之后立刻返回
+				assert.Equal(t, 2, calls)
+				return
+			}
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
 func TestToMap(t *testing.T) {
 	t.Run("integer-string to map[int]string", func(t *testing.T) {
 		elements := []string{"1", "2", "3", "4", "5"}
@@ -297,11 +616,23 @@ func TestToMap(t *testing.T) {
 	t.Run("传入nil slice,返回空map", func(t *testing.T) {
 		var elements []string = nil
 		resMap := ToMap(elements, func(str string) int {
-			num, _ := strconv.Atoi(str)
-			return num
+			t.Fatal("fn 不应该被调用")
+			return 0
 		})
+		require.NotNil(t, resMap)
 		epectedMap := make(map[int]string)
 		assert.Equal(t, epectedMap, resMap)
+		// 返回的 map 可以直接写入
+		resMap[1] = "1"
+	})
+
+	t.Run("传入空 slice,返回空map", func(t *testing.T) {
+		resMap := ToMap([]string{}, func(str string) int {
+			num, _ := strconv.Atoi(str)
+			return num
+		})
+		require.NotNil(t, resMap)
+		assert.Empty(t, resMap)
 	})
 }
 
@@ -346,3 +677,54 @@ func ExampleToMapV() {
 	fmt.Println(resMap)
 	// Output: map[a:{a b} c:{c d}]
 }
+
+func TestGroupBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		elements []int
+		want     map[bool][]int
+	}{
+		{
+			name: "nil",
+			want: map[bool][]int{},
+		},
+		{
+			name:     "empty",
+			elements: []int{},
+			want:     map[bool][]int{},
+		},
+		{
+			name:     "one group",
+			elements: []int{2, 4},
+			want: map[bool][]int{
+				true: {2, 4},
+			},
+		},
+		{
+			name:     "multiple groups",
+			elements: []int{1, 2, 3, 4, 5},
+			want: map[bool][]int{
+				true:  {2, 4},
+				false: {1, 3, 5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := GroupBy(tt.elements, func(element int) bool {
+				return element%2 == 0
+			})
+			assert.NotNil(t, res)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func ExampleGroupBy() {
+	elements := []string{"a", "bb", "c", "dd", "eee"}
+	resMap := GroupBy(elements, func(str string) int {
+		return len(str)
+	})
+	fmt.Println(resMap)
+	// Output: map[1:[a c] 2:[bb dd] 3:[eee]]
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParallelMap 和 Map 一样，但是会将 src 切分成若干段，交给最多 concurrency 个 goroutine 并发执行 m
+// 返回值的顺序和 src 保持一致
+// concurrency <= 0 的时候，使用 runtime.NumCPU() 作为并发度
+// 注意：m 会被并发调用，使用者需要自己保证 m 是并发安全的
+func ParallelMap[Src any, Dst any](src []Src, concurrency int, m func(idx int, src Src) Dst) []Dst {
+	dst := make([]Dst, len(src))
+	parallelRange(len(src), concurrency, func(start, end int) {
+		// 每个 goroutine 只写入自己负责的下标区间，所以不需要加锁
+		for i := start; i < end; i++ {
+			dst[i] = m(i, src[i])
+		}
+	})
+	return dst
+}
+
+// ParallelFilterMap 和 FilterMap 一样，但是会将 src 切分成若干段，交给最多 concurrency 个 goroutine 并发执行 m
+// 如果 m 的第二个返回值是 false，那么我们会忽略第一个返回值
+// 返回值的顺序和 src 保持一致，即使传入的切片为nil，也保证返回一个空切片而不是nil
+// concurrency <= 0 的时候，使用 runtime.NumCPU() 作为并发度
+// 注意：m 会被并发调用，使用者需要自己保证 m 是并发安全的
+func ParallelFilterMap[Src any, Dst any](src []Src, concurrency int, m func(idx int, src Src) (Dst, bool)) []Dst {
+	size := segmentSize(len(src), concurrency)
+	if size == 0 {
+		return []Dst{}
+	}
+	// 每一段使用自己的缓冲区，最后再按照段的顺序合并，这样既不需要加锁，也能保持顺序
+	buffers := make([][]Dst, (len(src)+size-1)/size)
+	parallelRange(len(src), concurrency, func(start, end int) {
+		buf := make([]Dst, 0, end-start)
+		for i := start; i < end; i++ {
+			if dst, ok := m(i, src[i]); ok {
+				buf = append(buf, dst)
+			}
+		}
+		buffers[start/size] = buf
+	})
+	total := 0
+	for _, buf := range buffers {
+		total += len(buf)
+	}
+	res := make([]Dst, 0, total)
+	for _, buf := range buffers {
+		res = append(res, buf...)
+	}
+	return res
+}
+
+// parallelRange 将 [0, n) 切分成最多 concurrency 段连续的区间，并发执行 fn，等待所有的 fn 返回
+// 除了最后一段，每一段的长度都是 segmentSize(n, concurrency)
+func parallelRange(n int, concurrency int, fn func(start, end int)) {
+	size := segmentSize(n, concurrency)
+	if size == 0 {
+		return
+	}
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += size {
+		end := min(start+size, n)
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// segmentSize 计算 parallelRange 中每一段的长度，n 为 0 的时候返回 0
+func segmentSize(n int, concurrency int) int {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	concurrency = min(concurrency, n)
+	if concurrency == 0 {
+		return 0
+	}
+	return (n + concurrency - 1) / concurrency
+}
@@ -0,0 +1,160 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelMap(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         []int
+		concurrency int
+		want        []string
+	}{
+		{
+			name:        "src nil",
+			concurrency: 4,
+			want:        []string{},
+		},
+		{
+			name:        "src empty",
+			src:         []int{},
+			concurrency: 4,
+			want:        []string{},
+		},
+		{
+			name:        "concurrency 1",
+			src:         []int{1, 2, 3},
+			concurrency: 1,
+			want:        []string{"0:1", "1:2", "2:3"},
+		},
+		{
+			name:        "concurrency larger than length",
+			src:         []int{1, 2, 3},
+			concurrency: 10,
+			want:        []string{"0:1", "1:2", "2:3"},
+		},
+		{
+			name:        "not divisible",
+			src:         []int{1, 2, 3, 4, 5, 6, 7},
+			concurrency: 3,
+			want:        []string{"0:1", "1:2", "2:3", "3:4", "4:5", "5:6", "6:7"},
+		},
+		{
+			name:        "default concurrency",
+			src:         []int{1, 2, 3, 4},
+			concurrency: 0,
+			want:        []string{"0:1", "1:2", "2:3", "3:4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			res := ParallelMap(tt.src, tt.concurrency, func(idx int, src int) string {
+				atomic.AddInt32(&calls, 1)
+				return strconv.Itoa(idx) + ":" + strconv.Itoa(src)
+			})
+			assert.Equal(t, tt.want, res)
+			assert.Equal(t, int32(len(tt.src)), calls)
+		})
+	}
+}
+
+func TestParallelFilterMap(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         []int
+		concurrency int
+		want        []string
+	}{
+		{
+			name:        "src nil",
+			concurrency: 4,
+			want:        []string{},
+		},
+		{
+			name:        "src empty",
+			src:         []int{},
+			concurrency: 4,
+			want:        []string{},
+		},
+		{
+			name:        "concurrency 1",
+			src:         []int{1, 2, 3},
+			concurrency: 1,
+			want:        []string{"1:2"},
+		},
+		{
+			name:        "concurrency larger than length",
+			src:         []int{1, 2, 3, 4},
+			concurrency: 10,
+			want:        []string{"1:2", "3:4"},
+		},
+		{
+			name:        "not divisible",
+			src:         []int{1, 2, 3, 4, 5, 6, 7, 8},
+			concurrency: 3,
+			want:        []string{"1:2", "3:4", "5:6", "7:8"},
+		},
+		{
+			name:        "all filtered",
+			src:         []int{1, 3, 5},
+			concurrency: 2,
+			want:        []string{},
+		},
+		{
+			name:        "default concurrency",
+			src:         []int{2, 4, 6},
+			concurrency: 0,
+			want:        []string{"0:2", "1:4", "2:6"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			res := ParallelFilterMap(tt.src, tt.concurrency, func(idx int, src int) (string, bool) {
+				atomic.AddInt32(&calls, 1)
+				return strconv.Itoa(idx) + ":" + strconv.Itoa(src), src%2 == 0
+			})
+			assert.Equal(t, tt.want, res)
+			assert.Equal(t, int32(len(tt.src)), calls)
+		})
+	}
+}
+
+func ExampleParallelFilterMap() {
+	src := []int{1, 2, 3, 4, 5, 6}
+	dst := ParallelFilterMap(src, 2, func(idx int, src int) (int, bool) {
+		return src * src, src%2 == 0
+	})
+	fmt.Println(dst)
+	// Output: [4 16 36]
+}
+
+func ExampleParallelMap() {
+	src := []int{1, 2, 3, 4}
+	dst := ParallelMap(src, 2, func(idx int, src int) int {
+		return src * src
+	})
+	fmt.Println(dst)
+	// Output: [1 4 9 16]
+}
@@ -0,0 +1,26 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+// Reduce 从左往右遍历 src，将每个元素合并到累加值 acc 上，返回最终的累加值
+// fn 的参数依次是当前的累加值、元素下标和元素
+// 如果 src 为 nil 或者为空，直接返回 init
+func Reduce[Src any, Acc any](src []Src, init Acc, fn func(acc Acc, idx int, src Src) Acc) Acc {
+	acc := init
+	for i, s := range src {
+		acc = fn(acc, i, s)
+	}
+	return acc
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReduce(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []int
+		init string
+		want string
+	}{
+		{
+			name: "src nil",
+			init: "init",
+			want: "init",
+		},
+		{
+			name: "src empty",
+			src:  []int{},
+			init: "init",
+			want: "init",
+		},
+		{
+			name: "src has element",
+			src:  []int{1, 2, 3},
+			init: "init",
+			want: "init,0:1,1:2,2:3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Reduce(tt.src, tt.init, func(acc string, idx int, src int) string {
+				return acc + "," + strconv.Itoa(idx) + ":" + strconv.Itoa(src)
+			})
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func ExampleReduce() {
+	src := []int{1, 2, 3}
+	sum := Reduce(src, 0, func(acc int, idx int, src int) int {
+		return acc + src
+	})
+	fmt.Println(sum)
+	// Output: 6
+}
@@ -15,6 +15,7 @@
 package slice
 
 // Reverse 将会完全创建一个新的切片，而不是直接在 src 上进行翻转。
+// 如果需要直接在 src 上翻转，请使用 ReverseSelf
 func Reverse[T any](src []T) []T {
 	var ret = make([]T, 0, len(src))
 	for i := len(src) - 1; i >= 0; i-- {
@@ -24,8 +25,27 @@ func Reverse[T any](src []T) []T {
 }
 
 // ReverseSelf 反转切片 直接在 src 上进行翻转。
+// src 为 nil 或者只有一个元素的时候什么也不会发生
 func ReverseSelf[T any](src []T) {
 	for i, j := 0, len(src)-1; i < j; i, j = i+1, j-1 {
 		src[i], src[j] = src[j], src[i]
 	}
 }
+
+// Rotate 将切片原地循环左移 n 位，例如 [1 2 3 4 5] 左移 2 位之后变成 [3 4 5 1 2]
+// n 为负数的时候循环右移 -n 位，n 的绝对值超过切片长度的时候按照长度取模
+// 使用三次翻转实现，时间复杂度 O(n)，不会分配额外的空间
+func Rotate[T any](src []T, n int) {
+	length := len(src)
+	if length == 0 {
+		return
+	}
+	// 右移 k 位等价于左移 length-k 位
+	n = (n%length + length) % length
+	if n == 0 {
+		return
+	}
+	ReverseSelf(src[:n])
+	ReverseSelf(src[n:])
+	ReverseSelf(src)
+}
@@ -46,8 +46,11 @@ func TestReverseInt(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			res := Reverse[int](tt.src)
-			assert.ElementsMatch(t, tt.want, res)
-			assert.NotSame(t, tt.src, res)
+			assert.Equal(t, tt.want, res)
+			// 返回的是一个新的切片，不会和 src 共享底层数组
+			if len(res) > 0 {
+				assert.NotSame(t, &tt.src[0], &res[0])
+			}
 		})
 	}
 }
@@ -81,8 +84,11 @@ func TestReverseStruct(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			res := Reverse[testStruct](tt.src)
-			assert.ElementsMatch(t, tt.want, res)
-			assert.NotSame(t, tt.src, res)
+			assert.Equal(t, tt.want, res)
+			// 返回的是一个新的切片，不会和 src 共享底层数组
+			if len(res) > 0 {
+				assert.NotSame(t, &tt.src[0], &res[0])
+			}
 		})
 	}
 }
@@ -151,6 +157,85 @@ func TestReverseSelfStruct(t *testing.T) {
 	}
 }
 
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []int
+		n    int
+		want []int
+	}{
+		{
+			name: "src nil",
+			n:    2,
+		},
+		{
+			name: "src empty",
+			src:  []int{},
+			n:    2,
+			want: []int{},
+		},
+		{
+			name: "zero",
+			src:  []int{1, 2, 3, 4, 5},
+			n:    0,
+			want: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "left",
+			src:  []int{1, 2, 3, 4, 5},
+			n:    2,
+			want: []int{3, 4, 5, 1, 2},
+		},
+		{
+			name: "right",
+			src:  []int{1, 2, 3, 4, 5},
+			n:    -2,
+			want: []int{4, 5, 1, 2, 3},
+		},
+		{
+			name: "length",
+			src:  []int{1, 2, 3, 4, 5},
+			n:    5,
+			want: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "left larger than length",
+			src:  []int{1, 2, 3, 4, 5},
+			n:    7,
+			want: []int{3, 4, 5, 1, 2},
+		},
+		{
+			name: "right larger than length",
+			src:  []int{1, 2, 3, 4, 5},
+			n:    -12,
+			want: []int{4, 5, 1, 2, 3},
+		},
+		{
+			name: "one element",
+			src:  []int{1},
+			n:    3,
+			want: []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Rotate(tt.src, tt.n)
+			assert.Equal(t, tt.want, tt.src)
+		})
+	}
+}
+
+func ExampleRotate() {
+	src := []int{1, 2, 3, 4, 5}
+	Rotate(src, 2)
+	fmt.Println(src)
+	Rotate(src, -2)
+	fmt.Println(src)
+	// Output:
+	// [3 4 5 1 2]
+	// [1 2 3 4 5]
+}
+
 func ExampleReverse() {
 	res := Reverse[int]([]int{1, 3, 2, 2, 4})
 	fmt.Println(res)
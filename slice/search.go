@@ -0,0 +1,41 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/go-generic"
+)
+
+// BinarySearch 在按照 cmp 升序排列的切片中二分查找 target，时间复杂度 O(logn)
+// 找到的时候返回 target 的下标和 true，如果存在多个相等的元素，返回第一个的下标；
+// 没有找到的时候返回 target 应该插入的位置和 false，插入之后切片依旧有序。空切片返回 0 和 false
+// 注意：如果 src 没有按照 cmp 排序，返回值是未定义的
+func BinarySearch[T any](src []T, target T, cmp generic.Comparator[T]) (int, bool) {
+	return slices.BinarySearchFunc(src, target, cmp)
+}
+
+// InsertSorted 将 val 插入到按照 cmp 升序排列的切片中，插入之后切片依旧有序
+// 使用二分查找确定插入的位置，如果存在和 val 相等的元素，val 会被插入到它们之后，所以相等元素的相对顺序保持不变
+// 和 Insert 一样，容量足够的时候会直接修改 src 的底层数组，所以调用者应该使用返回值
+// 注意：如果 src 没有按照 cmp 排序，插入的位置是未定义的
+func InsertSorted[T any](src []T, val T, cmp generic.Comparator[T]) []T {
+	idx := sort.Search(len(src), func(i int) bool {
+		return cmp(src[i], val) > 0
+	})
+	return slices.Insert(src, idx, val)
+}
@@ -0,0 +1,200 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinarySearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       []int
+		target    int
+		wantIdx   int
+		wantFound bool
+	}{
+		{
+			name:    "src nil",
+			target:  1,
+			wantIdx: 0,
+		},
+		{
+			name:    "src empty",
+			src:     []int{},
+			target:  1,
+			wantIdx: 0,
+		},
+		{
+			name:      "found first",
+			src:       []int{1, 3, 5, 7},
+			target:    1,
+			wantIdx:   0,
+			wantFound: true,
+		},
+		{
+			name:      "found last",
+			src:       []int{1, 3, 5, 7},
+			target:    7,
+			wantIdx:   3,
+			wantFound: true,
+		},
+		{
+			name:      "found middle",
+			src:       []int{1, 3, 5, 7, 9},
+			target:    5,
+			wantIdx:   2,
+			wantFound: true,
+		},
+		{
+			name:      "duplicate",
+			src:       []int{1, 3, 3, 3, 7},
+			target:    3,
+			wantIdx:   1,
+			wantFound: true,
+		},
+		{
+			name:    "less than all",
+			src:     []int{1, 3, 5},
+			target:  0,
+			wantIdx: 0,
+		},
+		{
+			name:    "greater than all",
+			src:     []int{1, 3, 5},
+			target:  6,
+			wantIdx: 3,
+		},
+		{
+			name:    "not found in middle",
+			src:     []int{1, 3, 5},
+			target:  4,
+			wantIdx: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx, found := BinarySearch(tt.src, tt.target, generic.ComparatorRealNumber[int])
+			assert.Equal(t, tt.wantIdx, idx)
+			assert.Equal(t, tt.wantFound, found)
+		})
+	}
+}
+
+func TestBinarySearchReverse(t *testing.T) {
+	// 降序的切片需要使用降序的比较函数
+	src := []int{9, 7, 5, 3}
+	idx, found := BinarySearch(src, 5, generic.Reverse(generic.ComparatorRealNumber[int]))
+	assert.Equal(t, 2, idx)
+	assert.True(t, found)
+	idx, found = BinarySearch(src, 6, generic.Reverse(generic.ComparatorRealNumber[int]))
+	assert.Equal(t, 2, idx)
+	assert.False(t, found)
+}
+
+func TestInsertSorted(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []int
+		val  int
+		want []int
+	}{
+		{
+			name: "src nil",
+			val:  1,
+			want: []int{1},
+		},
+		{
+			name: "src empty",
+			src:  []int{},
+			val:  1,
+			want: []int{1},
+		},
+		{
+			name: "insert at head",
+			src:  []int{2, 4, 6},
+			val:  1,
+			want: []int{1, 2, 4, 6},
+		},
+		{
+			name: "insert at tail",
+			src:  []int{2, 4, 6},
+			val:  7,
+			want: []int{2, 4, 6, 7},
+		},
+		{
+			name: "insert in middle",
+			src:  []int{2, 4, 6},
+			val:  5,
+			want: []int{2, 4, 5, 6},
+		},
+		{
+			name: "insert duplicate",
+			src:  []int{2, 4, 6},
+			val:  4,
+			want: []int{2, 4, 4, 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := InsertSorted(tt.src, tt.val, generic.ComparatorRealNumber[int])
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestInsertSortedStable(t *testing.T) {
+	type user struct {
+		name string
+		age  int
+	}
+	cmp := func(src, dst user) int {
+		return generic.ComparatorRealNumber(src.age, dst.age)
+	}
+	var users []user
+	users = InsertSorted(users, user{name: "a", age: 20}, cmp)
+	users = InsertSorted(users, user{name: "b", age: 10}, cmp)
+	users = InsertSorted(users, user{name: "c", age: 20}, cmp)
+	users = InsertSorted(users, user{name: "d", age: 10}, cmp)
+	// 相等的元素按照插入的顺序排列
+	assert.Equal(t, []user{
+		{name: "b", age: 10},
+		{name: "d", age: 10},
+		{name: "a", age: 20},
+		{name: "c", age: 20},
+	}, users)
+}
+
+func ExampleInsertSorted() {
+	var src []int
+	for _, val := range []int{5, 1, 4, 2, 3} {
+		src = InsertSorted(src, val, generic.ComparatorRealNumber[int])
+	}
+	fmt.Println(src)
+	// Output: [1 2 3 4 5]
+}
+
+func ExampleBinarySearch() {
+	src := []int{1, 3, 5, 7}
+	fmt.Println(BinarySearch(src, 5, generic.ComparatorRealNumber[int]))
+	fmt.Println(BinarySearch(src, 4, generic.ComparatorRealNumber[int]))
+	// Output:
+	// 2 true
+	// 2 false
+}
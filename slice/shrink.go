@@ -0,0 +1,61 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import "github.com/go-generic/internal/slice"
+
+// ShrinkPolicy 缩容策略，c 是切片的容量，l 是切片的长度
+// 返回缩容之后的容量，以及是否需要缩容
+type ShrinkPolicy = slice.ShrinkPolicy
+
+// DefaultShrinkPolicy 默认的缩容策略：
+// 容量 <= 64 的时候不缩容；
+// 容量 > 2048 并且长度没有达到容量的一半时，缩容到原来的 5/8；
+// 64 < 容量 <= 2048 并且长度没有达到容量的 1/4 时，缩容到原来的 1/2
+func DefaultShrinkPolicy(c, l int) (int, bool) {
+	return slice.DefaultShrinkPolicy(c, l)
+}
+
+// NoShrink 永远不缩容
+func NoShrink(c, l int) (int, bool) {
+	return slice.NoShrink(c, l)
+}
+
+// NewShrinkPolicy 创建一个缩容策略，DefaultShrinkPolicy 等价于 NewShrinkPolicy(64, 2048, 0.625, 0.5)
+// 容量 <= minCap 的时候不缩容；
+// 容量 > largeCap 并且长度没有达到容量的一半时，缩容到原来的 largeFactor 倍；
+// minCap < 容量 <= largeCap 并且长度没有达到容量的 1/4 时，缩容到原来的 smallFactor 倍
+func NewShrinkPolicy(minCap, largeCap int, largeFactor, smallFactor float64) ShrinkPolicy {
+	return slice.NewShrinkPolicy(minCap, largeCap, largeFactor, smallFactor)
+}
+
+// Shrink 使用 DefaultShrinkPolicy 对切片进行缩容
+// 需要缩容的时候会返回一个新的切片，否则直接返回 src
+func Shrink[T any](src []T) []T {
+	return slice.Shrink[T](src)
+}
+
+// ShrinkWithPolicy 使用 policy 对切片进行缩容
+// 需要缩容的时候会返回一个新的切片，否则直接返回 src
+func ShrinkWithPolicy[T any](src []T, policy ShrinkPolicy) []T {
+	return slice.ShrinkWithPolicy[T](src, policy)
+}
+
+// ShrinkToFit 返回一个容量和长度相等的新切片，释放所有多余的容量
+// 和 Shrink 不同，它不考虑任何阈值，总是会重新分配内存，适合确定切片之后不会再增长的场景
+// 即使传入的切片为nil，也保证返回一个空切片而不是nil
+func ShrinkToFit[T any](src []T) []T {
+	return slice.ShrinkToFit[T](src)
+}
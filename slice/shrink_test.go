@@ -0,0 +1,93 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShrinkWithPolicy(t *testing.T) {
+	testCases := []struct {
+		name      string
+		originCap int
+		length    int
+		policy    ShrinkPolicy
+		expectCap int
+	}{
+		{
+			name:      "默认策略，不缩容",
+			originCap: 64,
+			length:    1,
+			policy:    DefaultShrinkPolicy,
+			expectCap: 64,
+		},
+		{
+			name:      "默认策略，缩容",
+			originCap: 1000,
+			length:    10,
+			policy:    DefaultShrinkPolicy,
+			expectCap: 500,
+		},
+		{
+			name:      "不缩容",
+			originCap: 1000,
+			length:    10,
+			policy:    NoShrink,
+			expectCap: 1000,
+		},
+		{
+			name:      "自定义策略",
+			originCap: 1000,
+			length:    10,
+			policy:    NewShrinkPolicy(128, 512, 0.25, 0.5),
+			expectCap: 250,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := make([]int, tc.length, tc.originCap)
+			res := ShrinkWithPolicy(src, tc.policy)
+			assert.Equal(t, tc.expectCap, cap(res))
+			assert.Equal(t, src, res)
+		})
+	}
+}
+
+func TestShrink(t *testing.T) {
+	src := make([]int, 10, 1000)
+	assert.Equal(t, 500, cap(Shrink(src)))
+}
+
+func TestShrinkToFit(t *testing.T) {
+	src := make([]int, 10, 1000)
+	res := ShrinkToFit(src)
+	assert.Equal(t, 10, cap(res))
+	assert.Equal(t, src, res)
+	res = ShrinkToFit[int](nil)
+	assert.NotNil(t, res)
+	assert.Equal(t, 0, cap(res))
+}
+
+func ExampleShrinkWithPolicy() {
+	src := make([]int, 10, 1000)
+	fmt.Println(cap(Shrink(src)))
+	fmt.Println(cap(ShrinkWithPolicy(src, NoShrink)))
+	// Output:
+	// 500
+	// 1000
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import "math/rand"
+
+// Shuffle 使用 Fisher–Yates 算法原地打乱切片中元素的顺序
+// r 是随机数的来源，测试的时候可以传入固定种子的 r 得到确定的结果；r 为 nil 的时候使用 math/rand 包的全局随机数
+// 注意：*rand.Rand 不是并发安全的，不要在多个 goroutine 中共享同一个 r
+func Shuffle[T any](src []T, r *rand.Rand) {
+	for i := len(src) - 1; i > 0; i-- {
+		j := intn(r, i+1)
+		src[i], src[j] = src[j], src[i]
+	}
+}
+
+// Sample 从 src 中随机选出 n 个下标不同的元素，返回的元素顺序也是随机的，不会修改 src
+// n >= len(src) 的时候返回打乱顺序之后的所有元素；n <= 0 的时候返回一个空切片
+// r 的含义和 Shuffle 一致，r 为 nil 的时候使用 math/rand 包的全局随机数
+// n 远小于 len(src) 的时候不会复制整个 src，额外的内存只和 n 有关
+func Sample[T any](src []T, n int, r *rand.Rand) []T {
+	n = max(min(n, len(src)), 0)
+	if n*4 >= len(src) {
+		return sampleCopy(src, n, r)
+	}
+	return sampleSparse(src, n, r)
+}
+
+// sampleCopy 复制 src 之后执行 Fisher–Yates 的前 n 步，前 n 个元素就是随机选出的结果
+func sampleCopy[T any](src []T, n int, r *rand.Rand) []T {
+	res := make([]T, len(src))
+	copy(res, src)
+	for i := 0; i < n; i++ {
+		j := i + intn(r, len(res)-i)
+		res[i], res[j] = res[j], res[i]
+	}
+	return res[:n:n]
+}
+
+// sampleSparse 和 sampleCopy 执行同样的交换步骤，但是只用 map 记录被交换过的下标，
+// 所以在同样的 r 下两者的结果完全一致
+func sampleSparse[T any](src []T, n int, r *rand.Rand) []T {
+	res := make([]T, n)
+	// swapped[j] 表示下标 j 当前存放的是 src 中哪个下标的元素，不在 map 中的下标没有被交换过
+	swapped := make(map[int]int, n)
+	at := func(i int) int {
+		if v, ok := swapped[i]; ok {
+			return v
+		}
+		return i
+	}
+	for i := 0; i < n; i++ {
+		j := i + intn(r, len(src)-i)
+		vi, vj := at(i), at(j)
+		res[i] = src[vj]
+		// 下标 i 之后不会再被访问，只需要记录 j 换过来的元素
+		swapped[j] = vi
+	}
+	return res
+}
+
+// intn 返回 [0, n) 之间的随机数，r 为 nil 的时候使用全局随机数
+func intn(r *rand.Rand, n int) int {
+	if r == nil {
+		return rand.Intn(n)
+	}
+	return r.Intn(n)
+}
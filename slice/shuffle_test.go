@@ -0,0 +1,215 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShuffle(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []int
+		r    *rand.Rand
+	}{
+		{
+			name: "src nil",
+			r:    rand.New(rand.NewSource(1)),
+		},
+		{
+			name: "src empty",
+			src:  []int{},
+			r:    rand.New(rand.NewSource(1)),
+		},
+		{
+			name: "one element",
+			src:  []int{1},
+			r:    rand.New(rand.NewSource(1)),
+		},
+		{
+			name: "multiple",
+			src:  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			r:    rand.New(rand.NewSource(1)),
+		},
+		{
+			name: "nil rand",
+			src:  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := make([]int, len(tt.src))
+			copy(src, tt.src)
+			Shuffle(src, tt.r)
+			// 打乱之后元素不变，只是顺序变了
+			assert.ElementsMatch(t, tt.src, src)
+		})
+	}
+}
+
+func TestShuffleDeterministic(t *testing.T) {
+	newSrc := func() []int {
+		return []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	}
+	// 相同的种子得到相同的结果
+	src1, src2 := newSrc(), newSrc()
+	Shuffle(src1, rand.New(rand.NewSource(42)))
+	Shuffle(src2, rand.New(rand.NewSource(42)))
+	assert.Equal(t, src1, src2)
+
+	// 多次打乱，每个位置都出现过不同的元素，说明确实被打乱了
+	r := rand.New(rand.NewSource(42))
+	seen := make(map[int]struct{})
+	for i := 0; i < 100; i++ {
+		src := newSrc()
+		Shuffle(src, r)
+		seen[src[0]] = struct{}{}
+	}
+	assert.Len(t, seen, 10)
+}
+
+func TestSample(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     []int
+		n       int
+		r       *rand.Rand
+		wantLen int
+	}{
+		{
+			name:    "src nil",
+			n:       2,
+			r:       rand.New(rand.NewSource(1)),
+			wantLen: 0,
+		},
+		{
+			name:    "n negative",
+			src:     []int{1, 2, 3},
+			n:       -1,
+			r:       rand.New(rand.NewSource(1)),
+			wantLen: 0,
+		},
+		{
+			name:    "n zero",
+			src:     []int{1, 2, 3},
+			n:       0,
+			r:       rand.New(rand.NewSource(1)),
+			wantLen: 0,
+		},
+		{
+			name:    "n less than length",
+			src:     []int{1, 2, 3, 4, 5},
+			n:       3,
+			r:       rand.New(rand.NewSource(1)),
+			wantLen: 3,
+		},
+		{
+			name:    "n equals length",
+			src:     []int{1, 2, 3, 4, 5},
+			n:       5,
+			r:       rand.New(rand.NewSource(1)),
+			wantLen: 5,
+		},
+		{
+			name:    "n larger than length",
+			src:     []int{1, 2, 3, 4, 5},
+			n:       10,
+			r:       rand.New(rand.NewSource(1)),
+			wantLen: 5,
+		},
+		{
+			name:    "n much less than length",
+			src:     sequence(100),
+			n:       5,
+			r:       rand.New(rand.NewSource(1)),
+			wantLen: 5,
+		},
+		{
+			name:    "nil rand",
+			src:     []int{1, 2, 3, 4, 5},
+			n:       2,
+			wantLen: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := append([]int(nil), tt.src...)
+			res := Sample(src, tt.n, tt.r)
+			assert.NotNil(t, res)
+			assert.Len(t, res, tt.wantLen)
+			// 不会修改 src
+			assert.Equal(t, tt.src, src)
+			// 选出的元素来自 src，并且没有重复
+			assert.Subset(t, tt.src, res)
+			assert.Len(t, toMap(res), tt.wantLen)
+			if tt.wantLen == len(tt.src) {
+				assert.ElementsMatch(t, tt.src, res)
+			}
+		})
+	}
+}
+
+func TestSampleDistribution(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	src := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	seen := make(map[int]struct{})
+	for i := 0; i < 100; i++ {
+		for _, val := range Sample(src, 2, r) {
+			seen[val] = struct{}{}
+		}
+	}
+	// 每个元素都有机会被选中
+	assert.Len(t, seen, 10)
+
+	// 相同的种子得到相同的结果
+	assert.Equal(t, Sample(src, 3, rand.New(rand.NewSource(7))), Sample(src, 3, rand.New(rand.NewSource(7))))
+}
+
+func TestSampleSparse(t *testing.T) {
+	src := sequence(100)
+	for _, n := range []int{0, 1, 5, 50, 100} {
+		// 同样的种子，两种实现的结果一致
+		want := sampleCopy(src, n, rand.New(rand.NewSource(int64(n))))
+		got := sampleSparse(src, n, rand.New(rand.NewSource(int64(n))))
+		assert.Equal(t, want, got)
+	}
+	assert.Equal(t, sequence(100), src)
+}
+
+func sequence(n int) []int {
+	res := make([]int, n)
+	for i := range res {
+		res[i] = i
+	}
+	return res
+}
+
+func ExampleSample() {
+	src := []int{1, 2, 3, 4, 5}
+	res := Sample(src, 3, rand.New(rand.NewSource(1)))
+	fmt.Println(len(res), src)
+	// Output: 3 [1 2 3 4 5]
+}
+
+func ExampleShuffle() {
+	src := []int{1, 2, 3, 4, 5}
+	Shuffle(src, rand.New(rand.NewSource(1)))
+	fmt.Println(len(src))
+	// Output: 5
+}
@@ -59,5 +59,5 @@ func SymmetricDiffSetFunc[T any](src, dst []T, equal equalFunc[T]) []T {
 		}
 	}
 
-	return deduplicateFunc[T](res, equal)
+	return DeduplicateFunc[T](res, equal)
 }
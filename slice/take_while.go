@@ -0,0 +1,40 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+// TakeWhile 返回 src 开头连续满足 p 的元素，遇到第一个不满足 p 的元素就停止，不会继续遍历
+// 注意：返回的是 src 的子切片，和 src 共享底层数组，修改其中的元素会影响 src，
+// 但是子切片的容量被限制为自身的长度，所以对它执行 append 不会覆盖 src 中后续的元素
+func TakeWhile[T any](src []T, p matchFunc[T]) []T {
+	i := whileIndex(src, p)
+	return src[:i:i]
+}
+
+// DropWhile 跳过 src 开头连续满足 p 的元素，返回剩下的部分，遇到第一个不满足 p 的元素就停止，不会继续遍历
+// 和 TakeWhile 的结果拼接起来就是 src
+// 注意：返回的是 src 的子切片，和 src 共享底层数组
+func DropWhile[T any](src []T, p matchFunc[T]) []T {
+	return src[whileIndex(src, p):]
+}
+
+// whileIndex 返回第一个不满足 p 的元素的下标，如果所有元素都满足 p，返回 len(src)
+func whileIndex[T any](src []T, p matchFunc[T]) int {
+	for i, v := range src {
+		if !p(v) {
+			return i
+		}
+	}
+	return len(src)
+}
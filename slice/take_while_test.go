@@ -0,0 +1,127 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeWhile(t *testing.T) {
+	testCases := []struct {
+		name      string
+		src       []int
+		want      []int
+		wantCalls int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "empty",
+			src:  []int{},
+			want: []int{},
+		},
+		{
+			name:      "all matched",
+			src:       []int{1, 2, 3},
+			want:      []int{1, 2, 3},
+			wantCalls: 3,
+		},
+		{
+			name:      "none matched",
+			src:       []int{-1, 2, 3},
+			want:      []int{},
+			wantCalls: 1,
+		},
+		{
+			name:      "stop at first failure",
+			src:       []int{1, 2, -3, 4, -5},
+			want:      []int{1, 2},
+			wantCalls: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			res := TakeWhile(tc.src, func(src int) bool {
+				calls++
+				return src > 0
+			})
+			assert.Equal(t, tc.want, res)
+			assert.Equal(t, tc.wantCalls, calls)
+			assert.Equal(t, len(res), cap(res))
+		})
+	}
+}
+
+func TestDropWhile(t *testing.T) {
+	testCases := []struct {
+		name      string
+		src       []int
+		want      []int
+		wantCalls int
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "empty",
+			src:  []int{},
+			want: []int{},
+		},
+		{
+			name:      "all matched",
+			src:       []int{1, 2, 3},
+			want:      []int{},
+			wantCalls: 3,
+		},
+		{
+			name:      "none matched",
+			src:       []int{-1, 2, 3},
+			want:      []int{-1, 2, 3},
+			wantCalls: 1,
+		},
+		{
+			name:      "stop at first failure",
+			src:       []int{1, 2, -3, 4, -5},
+			want:      []int{-3, 4, -5},
+			wantCalls: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			res := DropWhile(tc.src, func(src int) bool {
+				calls++
+				return src > 0
+			})
+			assert.Equal(t, tc.want, res)
+			assert.Equal(t, tc.wantCalls, calls)
+		})
+	}
+}
+
+func ExampleTakeWhile() {
+	src := []int{1, 2, -3, 4}
+	positive := func(src int) bool {
+		return src > 0
+	}
+	fmt.Println(TakeWhile(src, positive), DropWhile(src, positive))
+	// Output:
+	// [1 2] [-3 4]
+}
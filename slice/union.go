@@ -39,5 +39,5 @@ func UnionSetFunc[T any](src, dst []T, equal equalFunc[T]) []T {
 	ret = append(ret, dst...)
 	ret = append(ret, src...)
 
-	return deduplicateFunc[T](ret, equal)
+	return DeduplicateFunc[T](ret, equal)
 }
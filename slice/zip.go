@@ -0,0 +1,41 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import "github.com/go-generic"
+
+// Zip 将两个切片按照下标一一配对，as 中的元素作为 Key，bs 中的元素作为 Value
+// 如果两个切片的长度不同，以较短的为准，多出来的元素会被忽略
+// 即使传入的切片为nil，也保证返回一个空切片而不是nil
+func Zip[A any, B any](as []A, bs []B) []generic.Pair[A, B] {
+	n := min(len(as), len(bs))
+	res := make([]generic.Pair[A, B], n)
+	for i := 0; i < n; i++ {
+		res[i] = generic.Pair[A, B]{Key: as[i], Value: bs[i]}
+	}
+	return res
+}
+
+// Unzip 是 Zip 的逆操作，将键值对拆分成两个切片，顺序保持不变
+// 即使传入的切片为nil，也保证返回两个空切片而不是nil
+func Unzip[A any, B any](pairs []generic.Pair[A, B]) ([]A, []B) {
+	as := make([]A, len(pairs))
+	bs := make([]B, len(pairs))
+	for i, p := range pairs {
+		as[i] = p.Key
+		bs[i] = p.Value
+	}
+	return as, bs
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-generic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZip(t *testing.T) {
+	testCases := []struct {
+		name string
+		as   []int
+		bs   []string
+		want []generic.Pair[int, string]
+	}{
+		{
+			name: "nil",
+			want: []generic.Pair[int, string]{},
+		},
+		{
+			name: "one is empty",
+			as:   []int{1, 2},
+			bs:   []string{},
+			want: []generic.Pair[int, string]{},
+		},
+		{
+			name: "same length",
+			as:   []int{1, 2},
+			bs:   []string{"a", "b"},
+			want: []generic.Pair[int, string]{{Key: 1, Value: "a"}, {Key: 2, Value: "b"}},
+		},
+		{
+			name: "as is shorter",
+			as:   []int{1},
+			bs:   []string{"a", "b"},
+			want: []generic.Pair[int, string]{{Key: 1, Value: "a"}},
+		},
+		{
+			name: "bs is shorter",
+			as:   []int{1, 2, 3},
+			bs:   []string{"a", "b"},
+			want: []generic.Pair[int, string]{{Key: 1, Value: "a"}, {Key: 2, Value: "b"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Zip(tc.as, tc.bs))
+		})
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	testCases := []struct {
+		name   string
+		pairs  []generic.Pair[int, string]
+		wantAs []int
+		wantBs []string
+	}{
+		{
+			name:   "nil",
+			wantAs: []int{},
+			wantBs: []string{},
+		},
+		{
+			name:   "multiple",
+			pairs:  []generic.Pair[int, string]{{Key: 1, Value: "a"}, {Key: 2, Value: "b"}},
+			wantAs: []int{1, 2},
+			wantBs: []string{"a", "b"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			as, bs := Unzip(tc.pairs)
+			assert.Equal(t, tc.wantAs, as)
+			assert.Equal(t, tc.wantBs, bs)
+		})
+	}
+}
+
+func ExampleZip() {
+	pairs := Zip([]int{1, 2, 3}, []string{"a", "b"})
+	for _, p := range pairs {
+		fmt.Println(p.Key, p.Value)
+	}
+	as, bs := Unzip(pairs)
+	fmt.Println(as, bs)
+	// Output:
+	// 1 a
+	// 2 b
+	// [1 2] [a b]
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"github.com/go-generic/internal/slice"
+)
+
+// Stack 基于切片实现的栈，遵循 LIFO，非并发安全
+// 出栈之后会按需缩容，缩容的策略和无界的优先队列一致
+type Stack[T any] struct {
+	data []T
+}
+
+// NewStack 创建一个栈，capacity 为预估的元素个数，capacity <= 0 的时候不预先分配
+func NewStack[T any](capacity int) *Stack[T] {
+	return &Stack[T]{
+		data: make([]T, 0, max(capacity, 0)),
+	}
+}
+
+// Push 入栈
+func (s *Stack[T]) Push(t T) {
+	s.data = append(s.data, t)
+}
+
+// Pop 出栈，返回栈顶元素
+// 如果栈为空，返回 ErrEmptyStack
+func (s *Stack[T]) Pop() (T, error) {
+	if len(s.data) == 0 {
+		var t T
+		return t, ErrEmptyStack
+	}
+	last := len(s.data) - 1
+	res := s.data[last]
+	// 清空引用，方便 GC
+	var zero T
+	s.data[last] = zero
+	s.data = slice.Shrink[T](s.data[:last])
+	return res, nil
+}
+
+// Peek 返回栈顶元素，但是不会将其出栈
+// 如果栈为空，返回 ErrEmptyStack
+func (s *Stack[T]) Peek() (T, error) {
+	if len(s.data) == 0 {
+		var t T
+		return t, ErrEmptyStack
+	}
+	return s.data[len(s.data)-1], nil
+}
+
+// Len 返回栈中的元素个数
+func (s *Stack[T]) Len() int {
+	return len(s.data)
+}
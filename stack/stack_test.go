@@ -0,0 +1,170 @@
+// Copyright 2021 ecodeclub
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStack_Push(t *testing.T) {
+	testCases := []struct {
+		name     string
+		s        *Stack[int]
+		vals     []int
+		wantData []int
+	}{
+		{
+			name:     "empty",
+			s:        NewStack[int](0),
+			vals:     []int{1},
+			wantData: []int{1},
+		},
+		{
+			name:     "negative capacity",
+			s:        NewStack[int](-1),
+			vals:     []int{1},
+			wantData: []int{1},
+		},
+		{
+			name:     "multiple",
+			s:        newStack(1, 2),
+			vals:     []int{3, 4},
+			wantData: []int{1, 2, 3, 4},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, val := range tc.vals {
+				tc.s.Push(val)
+			}
+			assert.Equal(t, tc.wantData, tc.s.data)
+			assert.Equal(t, len(tc.wantData), tc.s.Len())
+		})
+	}
+}
+
+func TestStack_Pop(t *testing.T) {
+	testCases := []struct {
+		name     string
+		s        *Stack[int]
+		wantVal  int
+		wantData []int
+		wantErr  error
+	}{
+		{
+			name:     "empty",
+			s:        NewStack[int](0),
+			wantData: []int{},
+			wantErr:  ErrEmptyStack,
+		},
+		{
+			name:     "single",
+			s:        newStack(1),
+			wantVal:  1,
+			wantData: []int{},
+		},
+		{
+			name:     "multiple",
+			s:        newStack(1, 2, 3),
+			wantVal:  3,
+			wantData: []int{1, 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := tc.s.Pop()
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantVal, val)
+			assert.Equal(t, tc.wantData, tc.s.data)
+		})
+	}
+}
+
+func TestStack_Peek(t *testing.T) {
+	testCases := []struct {
+		name    string
+		s       *Stack[int]
+		wantVal int
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			s:       NewStack[int](0),
+			wantErr: ErrEmptyStack,
+		},
+		{
+			name:    "multiple",
+			s:       newStack(1, 2, 3),
+			wantVal: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := tc.s.Len()
+			val, err := tc.s.Peek()
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantVal, val)
+			// 不会出栈
+			assert.Equal(t, l, tc.s.Len())
+		})
+	}
+}
+
+func TestStack_Shrink(t *testing.T) {
+	s := NewStack[int](0)
+	for i := 0; i < 1000; i++ {
+		s.Push(i)
+	}
+	c := cap(s.data)
+	for i := 999; i >= 0; i-- {
+		val, err := s.Pop()
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+	assert.Equal(t, 0, s.Len())
+	assert.Less(t, cap(s.data), c)
+	// 缩容之后依旧可以正常使用
+	s.Push(1)
+	val, err := s.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+}
+
+func newStack(vals ...int) *Stack[int] {
+	s := NewStack[int](len(vals))
+	for _, val := range vals {
+		s.Push(val)
+	}
+	return s
+}
+
+func ExampleNewStack() {
+	s := NewStack[int](3)
+	s.Push(1)
+	s.Push(2)
+	s.Push(3)
+	for s.Len() > 0 {
+		val, _ := s.Pop()
+		fmt.Println(val)
+	}
+	// Output:
+	// 3
+	// 2
+	// 1
+}